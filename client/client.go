@@ -0,0 +1,455 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultBackoff    = 100 * time.Millisecond
+	maxBackoff        = 5 * time.Second
+)
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	header     http.Header
+	maxRetries int
+	backoff    time.Duration
+}
+
+type Option func(*Client)
+
+func WithHTTPClient(c *http.Client) Option {
+	return func(cl *Client) {
+		cl.httpClient = c
+	}
+}
+
+func WithBearerToken(token string) Option {
+	return func(cl *Client) {
+		cl.header.Set("Authorization", "Bearer "+token)
+	}
+}
+
+func WithAPIKey(key string) Option {
+	return func(cl *Client) {
+		cl.header.Set("X-API-Key", key)
+	}
+}
+
+func WithHeader(key, value string) Option {
+	return func(cl *Client) {
+		cl.header.Set(key, value)
+	}
+}
+
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(cl *Client) {
+		cl.maxRetries = maxRetries
+		cl.backoff = backoff
+	}
+}
+
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		header:     http.Header{},
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func retryable(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func (c *Client) wait(ctx context.Context, attempt int, retryAfter string) error {
+	d := c.backoff << uint(attempt)
+	if d > maxBackoff || d <= 0 {
+		d = maxBackoff
+	}
+	d = d/2 + time.Duration(rand.Int63n(int64(d/2)+1)) // nolint: gosec
+	if s, err := strconv.Atoi(retryAfter); err == nil && s >= 0 {
+		d = time.Duration(s) * time.Second
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		if err != nil {
+			return err
+		}
+	}
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	retries := 0
+	if idempotent(method) {
+		retries = c.maxRetries
+	}
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		for k, v := range c.header {
+			req.Header[k] = v
+		}
+		if in != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			if attempt >= retries || ctx.Err() != nil {
+				return err
+			}
+			if err := c.wait(ctx, attempt, ""); err != nil {
+				return err
+			}
+			continue
+		}
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close() // nolint: errcheck
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode >= http.StatusBadRequest {
+			if attempt < retries && retryable(resp.StatusCode) {
+				if err := c.wait(ctx, attempt, resp.Header.Get("Retry-After")); err != nil {
+					return err
+				}
+				continue
+			}
+			return newAPIError(resp, data)
+		}
+		if out == nil || len(data) == 0 {
+			return nil
+		}
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("decode %s %s response: %w", method, path, err)
+		}
+		return nil
+	}
+}
+
+func pageQuery(limit, page int) url.Values {
+	return url.Values{
+		"limit":  {strconv.Itoa(limit)},
+		"offset": {strconv.Itoa(page)},
+	}
+}
+
+func (c *Client) GetPositions(ctx context.Context, limit, page int) ([]Position, error) {
+	var positions []Position
+	err := c.do(ctx, http.MethodGet, "/positions", pageQuery(limit, page), nil, &positions)
+	return positions, err
+}
+
+func (c *Client) GetEmployees(ctx context.Context, limit, page int) ([]Employee, error) {
+	var employees []Employee
+	err := c.do(ctx, http.MethodGet, "/employees", pageQuery(limit, page), nil, &employees)
+	return employees, err
+}
+
+func (c *Client) GetPosition(ctx context.Context, id string) (Position, error) {
+	var p Position
+	err := c.do(ctx, http.MethodGet, "/position/"+url.PathEscape(id), nil, nil, &p)
+	return p, err
+}
+
+func (c *Client) GetEmployee(ctx context.Context, id string) (Employee, error) {
+	var e Employee
+	err := c.do(ctx, http.MethodGet, "/employee/"+url.PathEscape(id), nil, nil, &e)
+	return e, err
+}
+
+func (c *Client) CreatePosition(ctx context.Context, p *Position) error {
+	return c.do(ctx, http.MethodPost, "/position", nil, p, &p.ID)
+}
+
+func (c *Client) CreateEmployee(ctx context.Context, e *Employee) error {
+	return c.do(ctx, http.MethodPost, "/employee", nil, e, &e.ID)
+}
+
+func (c *Client) UpdatePosition(ctx context.Context, p *Position) error {
+	return c.do(ctx, http.MethodPut, "/position", nil, p, p)
+}
+
+func (c *Client) UpdateEmployee(ctx context.Context, e *Employee) error {
+	return c.do(ctx, http.MethodPut, "/employee", nil, e, e)
+}
+
+func (c *Client) DeletePosition(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/position/"+url.PathEscape(id), nil, nil, nil)
+}
+
+func (c *Client) DeleteEmployee(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/employee/"+url.PathEscape(id), nil, nil, nil)
+}
+
+func (c *Client) employeeList(ctx context.Context, id, relation string) ([]Employee, error) {
+	var employees []Employee
+	err := c.do(ctx, http.MethodGet, "/employee/"+url.PathEscape(id)+"/"+relation, nil, nil, &employees)
+	return employees, err
+}
+
+func (c *Client) GetDirectReports(ctx context.Context, id string) ([]Employee, error) {
+	return c.employeeList(ctx, id, "reports")
+}
+
+func (c *Client) GetManagementChain(ctx context.Context, id string) ([]Employee, error) {
+	return c.employeeList(ctx, id, "chain")
+}
+
+func (c *Client) GetReportingSubtree(ctx context.Context, id string) ([]Employee, error) {
+	return c.employeeList(ctx, id, "subtree")
+}
+
+func (c *Client) OrgChart(ctx context.Context, rootID string) ([]*OrgChartNode, error) {
+	var nodes []*OrgChartNode
+	var query url.Values
+	if rootID != "" {
+		query = url.Values{"employee": {rootID}}
+	}
+	err := c.do(ctx, http.MethodGet, "/orgchart", query, nil, &nodes)
+	return nodes, err
+}
+
+func (c *Client) HireEmployee(ctx context.Context, e *Employee, effectiveDate string) error {
+	in := struct {
+		*Employee
+		EffectiveDate string `json:"effective_date,omitempty"`
+	}{e, effectiveDate}
+	return c.do(ctx, http.MethodPost, "/employee/hire", nil, in, e)
+}
+
+func (c *Client) RestoreEmployee(ctx context.Context, r *EmployeeRecord) error {
+	return c.do(ctx, http.MethodPost, "/employee/restore", nil, r, &r.Employee)
+}
+
+func (c *Client) changeStatus(ctx context.Context, id, action string, change LifecycleChange) (Employee, error) {
+	var e Employee
+	err := c.do(ctx, http.MethodPost, "/employee/"+url.PathEscape(id)+"/"+action, nil, change, &e)
+	return e, err
+}
+
+func (c *Client) TransferEmployee(ctx context.Context, id string, change LifecycleChange) (Employee, error) {
+	return c.changeStatus(ctx, id, "transfer", change)
+}
+
+func (c *Client) StartLeave(ctx context.Context, id string, change LifecycleChange) (Employee, error) {
+	return c.changeStatus(ctx, id, "leave", change)
+}
+
+func (c *Client) ReturnFromLeave(ctx context.Context, id string, change LifecycleChange) (Employee, error) {
+	return c.changeStatus(ctx, id, "return", change)
+}
+
+func (c *Client) TerminateEmployee(ctx context.Context, id string, change LifecycleChange) (Employee, error) {
+	return c.changeStatus(ctx, id, "terminate", change)
+}
+
+func (c *Client) RehireEmployee(ctx context.Context, id string, change LifecycleChange) (Employee, error) {
+	return c.changeStatus(ctx, id, "rehire", change)
+}
+
+func (c *Client) GetEmployeeEvents(ctx context.Context, id string) ([]LifecycleEvent, error) {
+	var events []LifecycleEvent
+	err := c.do(ctx, http.MethodGet, "/employee/"+url.PathEscape(id)+"/events", nil, nil, &events)
+	return events, err
+}
+
+func (c *Client) GetDepartments(ctx context.Context, limit, page int) ([]Department, error) {
+	var departments []Department
+	err := c.do(ctx, http.MethodGet, "/departments", pageQuery(limit, page), nil, &departments)
+	return departments, err
+}
+
+func (c *Client) GetDepartment(ctx context.Context, id string) (Department, error) {
+	var d Department
+	err := c.do(ctx, http.MethodGet, "/department/"+url.PathEscape(id), nil, nil, &d)
+	return d, err
+}
+
+func (c *Client) GetDepartmentEmployees(ctx context.Context, id string) ([]Employee, error) {
+	var employees []Employee
+	err := c.do(ctx, http.MethodGet, "/department/"+url.PathEscape(id)+"/employees", nil, nil, &employees)
+	return employees, err
+}
+
+func (c *Client) CreateDepartment(ctx context.Context, d *Department) error {
+	return c.do(ctx, http.MethodPost, "/department", nil, d, &d.ID)
+}
+
+func (c *Client) UpdateDepartment(ctx context.Context, d *Department) error {
+	return c.do(ctx, http.MethodPut, "/department", nil, d, d)
+}
+
+func (c *Client) DeleteDepartment(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/department/"+url.PathEscape(id), nil, nil, nil)
+}
+
+func (c *Client) GetExchangeRates(ctx context.Context, currency string) ([]ExchangeRate, error) {
+	var rates []ExchangeRate
+	var query url.Values
+	if currency != "" {
+		query = url.Values{"currency": {currency}}
+	}
+	err := c.do(ctx, http.MethodGet, "/rates", query, nil, &rates)
+	return rates, err
+}
+
+func (c *Client) SetExchangeRate(ctx context.Context, r *ExchangeRate) error {
+	return c.do(ctx, http.MethodPost, "/rate", nil, r, r)
+}
+
+func (c *Client) DeleteExchangeRate(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/rate/"+url.PathEscape(id), nil, nil, nil)
+}
+
+func (c *Client) GetPayGrades(ctx context.Context) ([]PayGrade, error) {
+	var grades []PayGrade
+	err := c.do(ctx, http.MethodGet, "/paygrades", nil, nil, &grades)
+	return grades, err
+}
+
+func (c *Client) GetPayGrade(ctx context.Context, id string) (PayGrade, error) {
+	var g PayGrade
+	err := c.do(ctx, http.MethodGet, "/paygrade/"+url.PathEscape(id), nil, nil, &g)
+	return g, err
+}
+
+func (c *Client) CreatePayGrade(ctx context.Context, g *PayGrade) error {
+	return c.do(ctx, http.MethodPost, "/paygrade", nil, g, &g.ID)
+}
+
+func (c *Client) UpdatePayGrade(ctx context.Context, g *PayGrade) error {
+	return c.do(ctx, http.MethodPut, "/paygrade", nil, g, g)
+}
+
+func (c *Client) DeletePayGrade(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/paygrade/"+url.PathEscape(id), nil, nil, nil)
+}
+
+func (c *Client) GetCompaRatios(ctx context.Context) ([]CompaRatio, error) {
+	var ratios []CompaRatio
+	err := c.do(ctx, http.MethodGet, "/reports/compa-ratio", nil, nil, &ratios)
+	return ratios, err
+}
+
+func (c *Client) GetPayrollReport(ctx context.Context, currency string) (PayrollReport, error) {
+	var report PayrollReport
+	var query url.Values
+	if currency != "" {
+		query = url.Values{"currency": {currency}}
+	}
+	err := c.do(ctx, http.MethodGet, "/reports/payroll", query, nil, &report)
+	return report, err
+}
+
+func (c *Client) GetHeadcountReport(ctx context.Context, from, to, interval string) (HeadcountReport, error) {
+	var report HeadcountReport
+	err := c.do(ctx, http.MethodGet, "/reports/headcount", reportQuery(from, to, interval), nil, &report)
+	return report, err
+}
+
+func (c *Client) GetTurnoverReport(ctx context.Context, from, to, interval string) (TurnoverReport, error) {
+	var report TurnoverReport
+	err := c.do(ctx, http.MethodGet, "/reports/turnover", reportQuery(from, to, interval), nil, &report)
+	return report, err
+}
+
+func reportQuery(from, to, interval string) url.Values {
+	query := url.Values{}
+	for name, value := range map[string]string{"from": from, "to": to, "interval": interval} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+	return query
+}
+
+func (c *Client) GetAttributeDefinitions(ctx context.Context, entity string) ([]AttributeDefinition, error) {
+	var definitions []AttributeDefinition
+	var query url.Values
+	if entity != "" {
+		query = url.Values{"entity": {entity}}
+	}
+	err := c.do(ctx, http.MethodGet, "/attributes", query, nil, &definitions)
+	return definitions, err
+}
+
+func (c *Client) CreateAttributeDefinition(ctx context.Context, d *AttributeDefinition) error {
+	return c.do(ctx, http.MethodPost, "/attribute", nil, d, &d.ID)
+}
+
+func (c *Client) UpdateAttributeDefinition(ctx context.Context, d *AttributeDefinition) error {
+	return c.do(ctx, http.MethodPut, "/attribute", nil, d, d)
+}
+
+func (c *Client) DeleteAttributeDefinition(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/attribute/"+url.PathEscape(id), nil, nil, nil)
+}
+
+func (c *Client) GetAPIKeys(ctx context.Context) ([]APIKey, error) {
+	var keys []APIKey
+	err := c.do(ctx, http.MethodGet, "/apikeys", nil, nil, &keys)
+	return keys, err
+}
+
+func (c *Client) CreateAPIKey(ctx context.Context, name string, scopes []string) (APIKeyWithSecret, error) {
+	var k APIKeyWithSecret
+	in := APIKey{Name: name, Scopes: scopes}
+	err := c.do(ctx, http.MethodPost, "/apikey", nil, in, &k)
+	return k, err
+}
+
+func (c *Client) RotateAPIKey(ctx context.Context, id string) (APIKeyWithSecret, error) {
+	var k APIKeyWithSecret
+	err := c.do(ctx, http.MethodPost, "/apikey/"+url.PathEscape(id)+"/rotate", nil, nil, &k)
+	return k, err
+}
+
+func (c *Client) RevokeAPIKey(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/apikey/"+url.PathEscape(id), nil, nil, nil)
+}
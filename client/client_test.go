@@ -0,0 +1,48 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/VTerenya/employees/client"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestOrgChart(t *testing.T) {
+	c := newTestServer(t)
+	ctx := context.Background()
+	p := client.Position{Name: "dev", Salary: decimal.NewFromInt(100)}
+	if err := c.CreatePosition(ctx, &p); err != nil {
+		t.Fatal(err)
+	}
+	boss := client.Employee{FirstName: "boss", LastName: "boss", PositionID: p.ID}
+	if err := c.CreateEmployee(ctx, &boss); err != nil {
+		t.Fatal(err)
+	}
+	ann := client.Employee{FirstName: "ann", LastName: "ann", PositionID: p.ID, ManagerID: &boss.ID}
+	if err := c.CreateEmployee(ctx, &ann); err != nil {
+		t.Fatal(err)
+	}
+	nodes, err := c.OrgChart(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 1 || nodes[0].ID != boss.ID || len(nodes[0].Reports) != 1 || nodes[0].Reports[0].ID != ann.ID {
+		t.Fatalf("org chart = %+v", nodes)
+	}
+	if nodes[0].Title != "dev" {
+		t.Fatalf("title = %q", nodes[0].Title)
+	}
+	nodes, err = c.OrgChart(ctx, ann.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 1 || nodes[0].ID != ann.ID || len(nodes[0].Reports) != 0 {
+		t.Fatalf("org chart from ann = %+v", nodes)
+	}
+	if _, err := c.OrgChart(ctx, uuid.New().String()); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("missing root: got %v", err)
+	}
+}
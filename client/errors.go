@@ -0,0 +1,117 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrBadRequest              = errors.New("bad request")                 // nolint: gochecknoglobals
+	ErrNotFound                = errors.New("not found")                   // nolint: gochecknoglobals
+	ErrPositionIsExists        = errors.New("position is exists")          // nolint: gochecknoglobals
+	ErrEmployeeIsExists        = errors.New("employee is exists")          // nolint: gochecknoglobals
+	ErrInternalServerError     = errors.New("internal server error")       // nolint: gochecknoglobals
+	ErrPositionIsNotExists     = errors.New("position is not exists")      // nolint: gochecknoglobals
+	ErrUnauthorized            = errors.New("unauthorized")                // nolint: gochecknoglobals
+	ErrForbidden               = errors.New("forbidden")                   // nolint: gochecknoglobals
+	ErrAPIKeyIsRevoked         = errors.New("api key is revoked")          // nolint: gochecknoglobals
+	ErrTooManyRequests         = errors.New("too many requests")           // nolint: gochecknoglobals
+	ErrDepartmentIsExists      = errors.New("department is exists")        // nolint: gochecknoglobals
+	ErrDepartmentIsNotExists   = errors.New("department is not exists")    // nolint: gochecknoglobals
+	ErrDepartmentIsNotEmpty    = errors.New("department is not empty")     // nolint: gochecknoglobals
+	ErrManagerIsNotExists      = errors.New("manager is not exists")       // nolint: gochecknoglobals
+	ErrManagerCycle            = errors.New("manager cycle")               // nolint: gochecknoglobals
+	ErrEmployeeHasReports      = errors.New("employee has reports")        // nolint: gochecknoglobals
+	ErrInvalidTransition       = errors.New("invalid status transition")   // nolint: gochecknoglobals
+	ErrEmailIsExists           = errors.New("email is exists")             // nolint: gochecknoglobals
+	ErrEmployeeNumberIsExists  = errors.New("employee number is exists")   // nolint: gochecknoglobals
+	ErrAttributeIsExists       = errors.New("attribute is exists")         // nolint: gochecknoglobals
+	ErrInvalidAttribute        = errors.New("invalid attribute")           // nolint: gochecknoglobals
+	ErrPayGradeIsExists        = errors.New("pay grade is exists")         // nolint: gochecknoglobals
+	ErrPayGradeIsNotExists     = errors.New("pay grade is not exists")     // nolint: gochecknoglobals
+	ErrPayGradeIsNotEmpty      = errors.New("pay grade is not empty")      // nolint: gochecknoglobals
+	ErrSalaryOutOfBand         = errors.New("salary is out of band")       // nolint: gochecknoglobals
+	ErrExchangeRateIsNotExists = errors.New("exchange rate is not exists") // nolint: gochecknoglobals
+	ErrEmployeeIsNotTerminated = errors.New("employee is not terminated")  // nolint: gochecknoglobals
+	ErrPositionNeedsTransfer   = errors.New("position needs transfer")     // nolint: gochecknoglobals
+)
+
+type APIError struct {
+	StatusCode    int
+	Message       string
+	CorrelationID string
+	Problem       *Problem
+	sentinel      error
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+func (e *APIError) Unwrap() error {
+	return e.sentinel
+}
+
+func sentinelByMessage(msg string) error {
+	for _, err := range []error{
+		ErrBadRequest, ErrNotFound, ErrPositionIsExists, ErrEmployeeIsExists,
+		ErrInternalServerError, ErrPositionIsNotExists, ErrUnauthorized, ErrForbidden,
+		ErrAPIKeyIsRevoked, ErrDepartmentIsExists, ErrDepartmentIsNotExists, ErrDepartmentIsNotEmpty,
+		ErrManagerIsNotExists, ErrManagerCycle, ErrEmployeeHasReports, ErrInvalidTransition,
+		ErrEmailIsExists, ErrEmployeeNumberIsExists, ErrAttributeIsExists, ErrInvalidAttribute,
+		ErrPayGradeIsExists, ErrPayGradeIsNotExists, ErrPayGradeIsNotEmpty, ErrSalaryOutOfBand,
+		ErrExchangeRateIsNotExists, ErrEmployeeIsNotTerminated, ErrPositionNeedsTransfer,
+	} {
+		if err.Error() == msg {
+			return err
+		}
+	}
+	return nil
+}
+
+func sentinelByStatus(status int) error {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrBadRequest
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrTooManyRequests
+	}
+	if status >= http.StatusInternalServerError {
+		return ErrInternalServerError
+	}
+	return nil
+}
+
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode:    resp.StatusCode,
+		Message:       strings.TrimSpace(string(body)),
+		CorrelationID: resp.Header.Get("X-Request-ID"),
+	}
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/problem+json") {
+		var p Problem
+		if err := json.Unmarshal(body, &p); err == nil {
+			e.Problem = &p
+			e.Message = p.Title
+			if p.Detail != "" {
+				e.Message = p.Detail
+			}
+		}
+	}
+	if e.Message == "" {
+		e.Message = http.StatusText(resp.StatusCode)
+	}
+	e.sentinel = sentinelByMessage(e.Message)
+	if e.sentinel == nil {
+		e.sentinel = sentinelByStatus(resp.StatusCode)
+	}
+	return e
+}
@@ -0,0 +1,29 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestAPIErrorSentinels(t *testing.T) {
+	for _, tt := range []struct {
+		status int
+		body   string
+		want   error
+	}{
+		{http.StatusConflict, "email is exists\n", ErrEmailIsExists},
+		{http.StatusConflict, "employee number is exists\n", ErrEmployeeNumberIsExists},
+		{http.StatusConflict, "employee is not terminated\n", ErrEmployeeIsNotTerminated},
+		{http.StatusConflict, "position needs transfer\n", ErrPositionNeedsTransfer},
+		{http.StatusConflict, "employee is exists\n", ErrEmployeeIsExists},
+		{http.StatusNotFound, "no such thing\n", ErrNotFound},
+		{http.StatusTooManyRequests, "", ErrTooManyRequests},
+	} {
+		resp := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+		err := newAPIError(resp, []byte(tt.body))
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%d %q: got %v, want %v", tt.status, tt.body, err.sentinel, tt.want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"errors"
+)
+
+const defaultPageSize = 100
+
+type pager struct {
+	limit int
+	page  int
+	done  bool
+	err   error
+	index int
+}
+
+func (p *pager) next(ctx context.Context, size int, fetch func(ctx context.Context, limit, page int) (int, error)) bool {
+	if p.index+1 < size {
+		p.index++
+		return true
+	}
+	if p.done || p.err != nil {
+		return false
+	}
+	p.page++
+	n, err := fetch(ctx, p.limit, p.page)
+	if err != nil {
+		p.done = true
+		if !errors.Is(err, ErrNotFound) {
+			p.err = err
+		}
+		return false
+	}
+	if n < p.limit {
+		p.done = true
+	}
+	p.index = 0
+	return n > 0
+}
+
+type PositionIterator struct {
+	c       *Client
+	p       pager
+	current []Position
+}
+
+func (c *Client) Positions(pageSize int) *PositionIterator {
+	if pageSize <= 0 || pageSize > defaultPageSize {
+		pageSize = defaultPageSize
+	}
+	return &PositionIterator{c: c, p: pager{limit: pageSize}}
+}
+
+func (it *PositionIterator) Next(ctx context.Context) bool {
+	return it.p.next(ctx, len(it.current), func(ctx context.Context, limit, page int) (int, error) {
+		positions, err := it.c.GetPositions(ctx, limit, page)
+		if err == nil {
+			it.current = positions
+		}
+		return len(positions), err
+	})
+}
+
+func (it *PositionIterator) Position() Position {
+	return it.current[it.p.index]
+}
+
+func (it *PositionIterator) Err() error {
+	return it.p.err
+}
+
+type EmployeeIterator struct {
+	c       *Client
+	p       pager
+	current []Employee
+}
+
+func (c *Client) Employees(pageSize int) *EmployeeIterator {
+	if pageSize <= 0 || pageSize > defaultPageSize {
+		pageSize = defaultPageSize
+	}
+	return &EmployeeIterator{c: c, p: pager{limit: pageSize}}
+}
+
+func (it *EmployeeIterator) Next(ctx context.Context) bool {
+	return it.p.next(ctx, len(it.current), func(ctx context.Context, limit, page int) (int, error) {
+		employees, err := it.c.GetEmployees(ctx, limit, page)
+		if err == nil {
+			it.current = employees
+		}
+		return len(employees), err
+	})
+}
+
+func (it *EmployeeIterator) Employee() Employee {
+	return it.current[it.p.index]
+}
+
+func (it *EmployeeIterator) Err() error {
+	return it.p.err
+}
+
+type DepartmentIterator struct {
+	c       *Client
+	p       pager
+	current []Department
+}
+
+func (c *Client) Departments(pageSize int) *DepartmentIterator {
+	if pageSize <= 0 || pageSize > defaultPageSize {
+		pageSize = defaultPageSize
+	}
+	return &DepartmentIterator{c: c, p: pager{limit: pageSize}}
+}
+
+func (it *DepartmentIterator) Next(ctx context.Context) bool {
+	return it.p.next(ctx, len(it.current), func(ctx context.Context, limit, page int) (int, error) {
+		departments, err := it.c.GetDepartments(ctx, limit, page)
+		if err == nil {
+			it.current = departments
+		}
+		return len(departments), err
+	})
+}
+
+func (it *DepartmentIterator) Department() Department {
+	return it.current[it.p.index]
+}
+
+func (it *DepartmentIterator) Err() error {
+	return it.p.err
+}
@@ -0,0 +1,116 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VTerenya/employees/client"
+	"github.com/VTerenya/employees/internal/handler"
+	"github.com/VTerenya/employees/internal/middleware"
+	"github.com/VTerenya/employees/internal/repository"
+	"github.com/VTerenya/employees/internal/service"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/shopspring/decimal"
+)
+
+func newTestServer(t *testing.T) *client.Client {
+	t.Helper()
+	h := handler.NewHandler(service.NewServ(repository.NewRepo(repository.NewDataBase()), ""))
+	page := []string{"limit", "{limit:\\S+}", "offset", "{offset:\\S+}"}
+	r := mux.NewRouter()
+	r.HandleFunc("/positions", h.GetPositions).Queries(page...).Methods("GET")
+	r.HandleFunc("/employees", h.GetEmployees).Queries(page...).Methods("GET")
+	r.HandleFunc("/departments", h.GetDepartments).Queries(page...).Methods("GET")
+	r.HandleFunc("/position", h.CreatePosition).Methods("POST")
+	r.HandleFunc("/employee", h.CreateEmployee).Methods("POST")
+	r.HandleFunc("/department", h.CreateDepartment).Methods("POST")
+	r.HandleFunc("/orgchart", h.GetOrgChart).Methods("GET")
+	r.Use(middleware.IDMiddleware, middleware.NewAnonymousMiddleware())
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return client.New(srv.URL)
+}
+
+func seed(t *testing.T, c *client.Client, n int) {
+	t.Helper()
+	ctx := context.Background()
+	for i := 0; i < n; i++ {
+		p := client.Position{Name: fmt.Sprintf("position %d", i), Salary: decimal.NewFromInt(int64(i + 1))}
+		if err := c.CreatePosition(ctx, &p); err != nil {
+			t.Fatal(err)
+		}
+		e := client.Employee{FirstName: "first", LastName: fmt.Sprintf("last %d", i), PositionID: p.ID}
+		if err := c.CreateEmployee(ctx, &e); err != nil {
+			t.Fatal(err)
+		}
+		d := client.Department{Name: fmt.Sprintf("department %d", i)}
+		if err := c.CreateDepartment(ctx, &d); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func checkUnique(t *testing.T, what string, ids []uuid.UUID, want int) {
+	t.Helper()
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			t.Fatalf("%s: %s returned twice", what, id)
+		}
+		seen[id] = true
+	}
+	if len(ids) != want {
+		t.Fatalf("%s: got %d records, want %d", what, len(ids), want)
+	}
+}
+
+func TestIteratorsVisitEveryRecordOnce(t *testing.T) {
+	const records = 230
+	c := newTestServer(t)
+	seed(t, c, records)
+	ctx := context.Background()
+	for _, pageSize := range []int{7, 100, 0} {
+		var positions, employees, departments []uuid.UUID
+		for it := c.Positions(pageSize); it.Next(ctx); {
+			positions = append(positions, it.Position().ID)
+		}
+		for it := c.Employees(pageSize); it.Next(ctx); {
+			employees = append(employees, it.Employee().ID)
+		}
+		it := c.Departments(pageSize)
+		for it.Next(ctx) {
+			departments = append(departments, it.Department().ID)
+		}
+		if err := it.Err(); err != nil {
+			t.Fatal(err)
+		}
+		checkUnique(t, fmt.Sprintf("positions/%d", pageSize), positions, records)
+		checkUnique(t, fmt.Sprintf("employees/%d", pageSize), employees, records)
+		checkUnique(t, fmt.Sprintf("departments/%d", pageSize), departments, records)
+	}
+}
+
+func TestIteratorEmptyAndExactPages(t *testing.T) {
+	c := newTestServer(t)
+	ctx := context.Background()
+	it := c.Positions(10)
+	if it.Next(ctx) {
+		t.Fatal("empty collection yielded a record")
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("empty collection: %v", err)
+	}
+	seed(t, c, 20)
+	var ids []uuid.UUID
+	it = c.Positions(10)
+	for it.Next(ctx) {
+		ids = append(ids, it.Position().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	checkUnique(t, "positions", ids, 20)
+}
@@ -0,0 +1,233 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type Position struct {
+	ID                   uuid.UUID              `json:"id"`
+	Name                 string                 `json:"name"`
+	Salary               decimal.Decimal        `json:"salary"`
+	Currency             string                 `json:"currency,omitempty"`
+	DepartmentID         *uuid.UUID             `json:"department_id,omitempty"`
+	PayGradeID           *uuid.UUID             `json:"pay_grade_id,omitempty"`
+	SalaryOverrideReason string                 `json:"salary_override_reason,omitempty"`
+	Attributes           map[string]interface{} `json:"attributes,omitempty"`
+}
+
+type PayGrade struct {
+	ID       uuid.UUID       `json:"id"`
+	Name     string          `json:"name"`
+	Min      decimal.Decimal `json:"min"`
+	Mid      decimal.Decimal `json:"mid"`
+	Max      decimal.Decimal `json:"max"`
+	Currency string          `json:"currency,omitempty"`
+}
+
+type CompaRatio struct {
+	PositionID     uuid.UUID       `json:"position_id"`
+	PositionName   string          `json:"position_name"`
+	PayGradeID     uuid.UUID       `json:"pay_grade_id"`
+	PayGradeName   string          `json:"pay_grade_name"`
+	Salary         decimal.Decimal `json:"salary"`
+	Min            decimal.Decimal `json:"min"`
+	Mid            decimal.Decimal `json:"mid"`
+	Max            decimal.Decimal `json:"max"`
+	Currency       string          `json:"currency"`
+	CompaRatio     decimal.Decimal `json:"compa_ratio"`
+	InBand         bool            `json:"in_band"`
+	OverrideReason string          `json:"override_reason,omitempty"`
+}
+
+type PayrollStats struct {
+	Headcount int             `json:"headcount"`
+	Total     decimal.Decimal `json:"total"`
+	Average   decimal.Decimal `json:"average"`
+	Median    decimal.Decimal `json:"median"`
+	Min       decimal.Decimal `json:"min"`
+	Max       decimal.Decimal `json:"max"`
+}
+
+type PositionPayroll struct {
+	PositionID   uuid.UUID  `json:"position_id"`
+	PositionName string     `json:"position_name"`
+	DepartmentID *uuid.UUID `json:"department_id,omitempty"`
+	PayrollStats
+}
+
+type DepartmentPayroll struct {
+	DepartmentID   *uuid.UUID `json:"department_id,omitempty"`
+	DepartmentName string     `json:"department_name,omitempty"`
+	PayrollStats
+}
+
+type PayrollReport struct {
+	Currency string `json:"currency"`
+	AsOf     string `json:"as_of"`
+	PayrollStats
+	Positions   []PositionPayroll   `json:"positions"`
+	Departments []DepartmentPayroll `json:"departments"`
+}
+
+type PositionHeadcount struct {
+	PositionID   uuid.UUID `json:"position_id"`
+	PositionName string    `json:"position_name,omitempty"`
+	Headcount    int       `json:"headcount"`
+	Hires        int       `json:"hires"`
+	Terminations int       `json:"terminations"`
+}
+
+type HeadcountPeriod struct {
+	Start        string              `json:"start"`
+	End          string              `json:"end"`
+	Headcount    int                 `json:"headcount"`
+	Hires        int                 `json:"hires"`
+	Terminations int                 `json:"terminations"`
+	Positions    []PositionHeadcount `json:"positions"`
+}
+
+type HeadcountReport struct {
+	From     string            `json:"from"`
+	To       string            `json:"to"`
+	Interval string            `json:"interval"`
+	Periods  []HeadcountPeriod `json:"periods"`
+}
+
+type TurnoverStats struct {
+	HeadcountStart    int             `json:"headcount_start"`
+	HeadcountEnd      int             `json:"headcount_end"`
+	AverageHeadcount  decimal.Decimal `json:"average_headcount"`
+	Hires             int             `json:"hires"`
+	Terminations      int             `json:"terminations"`
+	TurnoverRate      decimal.Decimal `json:"turnover_rate"`
+	AverageTenureDays decimal.Decimal `json:"average_tenure_days"`
+}
+
+type PositionTurnover struct {
+	PositionID   uuid.UUID `json:"position_id"`
+	PositionName string    `json:"position_name,omitempty"`
+	TurnoverStats
+}
+
+type TurnoverReport struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Interval string `json:"interval"`
+	TurnoverStats
+	Positions []PositionTurnover `json:"positions"`
+}
+
+type Department struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	CostCenter string    `json:"cost_center,omitempty"`
+}
+
+type Employee struct {
+	ID             uuid.UUID              `json:"id"`
+	FirstName      string                 `json:"first_name"`
+	LastName       string                 `json:"last_name"`
+	PositionID     uuid.UUID              `json:"position_id"`
+	ManagerID      *uuid.UUID             `json:"manager_id,omitempty"`
+	Status         string                 `json:"status,omitempty"`
+	Email          string                 `json:"email,omitempty"`
+	Phone          string                 `json:"phone,omitempty"`
+	DateOfBirth    string                 `json:"date_of_birth,omitempty"`
+	HireDate       string                 `json:"hire_date,omitempty"`
+	EmploymentType string                 `json:"employment_type,omitempty"`
+	WorkLocation   string                 `json:"work_location,omitempty"`
+	EmployeeNumber string                 `json:"employee_number,omitempty"`
+	Attributes     map[string]interface{} `json:"attributes,omitempty"`
+}
+
+func (e *Employee) UnmarshalJSON(data []byte) error {
+	type employee Employee
+	aux := struct {
+		*employee
+		LasName string `json:"las_name"`
+	}{employee: (*employee)(e)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if e.LastName == "" {
+		e.LastName = aux.LasName
+	}
+	return nil
+}
+
+type OrgChartNode struct {
+	ID         uuid.UUID       `json:"id"`
+	FirstName  string          `json:"first_name"`
+	LastName   string          `json:"last_name"`
+	PositionID uuid.UUID       `json:"position_id"`
+	Title      string          `json:"title,omitempty"`
+	Reports    []*OrgChartNode `json:"reports,omitempty"`
+}
+
+type ExchangeRate struct {
+	ID            uuid.UUID       `json:"id"`
+	Currency      string          `json:"currency"`
+	Rate          decimal.Decimal `json:"rate"`
+	EffectiveDate string          `json:"effective_date,omitempty"`
+	RecordedAt    time.Time       `json:"recorded_at"`
+}
+
+type AttributeDefinition struct {
+	ID            uuid.UUID `json:"id"`
+	Entity        string    `json:"entity"`
+	Name          string    `json:"name"`
+	Type          string    `json:"type"`
+	Required      bool      `json:"required"`
+	AllowedValues []string  `json:"allowed_values,omitempty"`
+}
+
+type LifecycleChange struct {
+	PositionID    *uuid.UUID `json:"position_id,omitempty"`
+	EffectiveDate string     `json:"effective_date,omitempty"`
+	Reason        string     `json:"reason,omitempty"`
+}
+
+type LifecycleEvent struct {
+	ID             uuid.UUID  `json:"id"`
+	EmployeeID     uuid.UUID  `json:"employee_id"`
+	Type           string     `json:"type"`
+	EffectiveDate  string     `json:"effective_date"`
+	FromPositionID *uuid.UUID `json:"from_position_id,omitempty"`
+	ToPositionID   *uuid.UUID `json:"to_position_id,omitempty"`
+	Reason         string     `json:"reason,omitempty"`
+	RecordedAt     time.Time  `json:"recorded_at"`
+}
+
+type EmployeeRecord struct {
+	Employee Employee         `json:"employee"`
+	Events   []LifecycleEvent `json:"events,omitempty"`
+}
+
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RotatedAt  *time.Time `json:"rotated_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+type APIKeyWithSecret struct {
+	APIKey
+	Secret string `json:"secret"`
+}
+
+type Problem struct {
+	Type          string `json:"type"`
+	Title         string `json:"title"`
+	Status        int    `json:"status"`
+	Detail        string `json:"detail,omitempty"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+const bashCompletion = `_employeesctl() {
+    local cur prev words cword
+    COMPREPLY=()
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+    case "${prev}" in
+        -o)
+            COMPREPLY=($(compgen -W "table json yaml" -- "${cur}"))
+            return 0
+            ;;
+        positions|position|employees|employee)
+            COMPREPLY=($(compgen -W "list get create update delete" -- "${cur}"))
+            return 0
+            ;;
+        profiles|profile)
+            COMPREPLY=($(compgen -W "list use set" -- "${cur}"))
+            return 0
+            ;;
+        completion)
+            COMPREPLY=($(compgen -W "bash zsh" -- "${cur}"))
+            return 0
+            ;;
+        create|update)
+            COMPREPLY=($(compgen -W "-name -salary -first-name -last-name -position" -- "${cur}"))
+            return 0
+            ;;
+        export|import)
+            COMPREPLY=($(compgen -W "-file" -- "${cur}"))
+            return 0
+            ;;
+    esac
+    COMPREPLY=($(compgen -W "positions employees export import profiles completion -profile -server -token -api-key -o" -- "${cur}"))
+}
+complete -F _employeesctl employeesctl
+`
+
+const zshCompletion = `autoload -U +X bashcompinit && bashcompinit
+` + bashCompletion
+
+func completion(out io.Writer, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected a shell: bash or zsh")
+	}
+	switch args[0] {
+	case "bash":
+		_, err := io.WriteString(out, bashCompletion)
+		return err
+	case "zsh":
+		_, err := io.WriteString(out, zshCompletion)
+		return err
+	}
+	return fmt.Errorf("unsupported shell %q", args[0])
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/VTerenya/employees/client"
+	"github.com/google/uuid"
+)
+
+func employeeTable(employees ...client.Employee) table {
+	t := table{header: []string{"ID", "FIRST NAME", "LAST NAME", "EMAIL", "POSITION ID", "STATUS"}}
+	for _, e := range employees {
+		t.rows = append(t.rows, []string{
+			e.ID.String(), e.FirstName, e.LastName, e.Email, e.PositionID.String(), e.Status,
+		})
+	}
+	return t
+}
+
+func (a *app) listEmployees() ([]client.Employee, error) {
+	employees := make([]client.Employee, 0)
+	it := a.client.Employees(0)
+	for it.Next(a.ctx) {
+		employees = append(employees, it.Employee())
+	}
+	return employees, it.Err()
+}
+
+func (a *app) employees(args []string) error {
+	v, rest, err := verb(args)
+	if err != nil {
+		return err
+	}
+	switch v {
+	case "list":
+		employees, err := a.listEmployees()
+		if err != nil {
+			return err
+		}
+		return a.printer.print(employees, employeeTable(employees...))
+	case "get":
+		id, err := oneID(rest)
+		if err != nil {
+			return err
+		}
+		e, err := a.client.GetEmployee(a.ctx, id)
+		if err != nil {
+			return err
+		}
+		return a.printer.print(e, employeeTable(e))
+	case "create":
+		var e client.Employee
+		if err := employeeFlags(&e, rest, true); err != nil {
+			return err
+		}
+		if err := a.client.CreateEmployee(a.ctx, &e); err != nil {
+			return err
+		}
+		return a.printer.print(e, employeeTable(e))
+	case "update":
+		if len(rest) == 0 {
+			return fmt.Errorf("expected an id")
+		}
+		e, err := a.client.GetEmployee(a.ctx, rest[0])
+		if err != nil {
+			return err
+		}
+		position := e.PositionID
+		if err := employeeFlags(&e, rest[1:], false); err != nil {
+			return err
+		}
+		if e.PositionID != position {
+			change := client.LifecycleChange{PositionID: &e.PositionID}
+			if _, err := a.client.TransferEmployee(a.ctx, e.ID.String(), change); err != nil {
+				return err
+			}
+		}
+		if err := a.client.UpdateEmployee(a.ctx, &e); err != nil {
+			return err
+		}
+		return a.printer.print(e, employeeTable(e))
+	case "delete":
+		id, err := oneID(rest)
+		if err != nil {
+			return err
+		}
+		return a.client.DeleteEmployee(a.ctx, id)
+	}
+	return fmt.Errorf("unknown employees subcommand %q", v)
+}
+
+func employeeFlags(e *client.Employee, args []string, required bool) error {
+	fs := flag.NewFlagSet("employee", flag.ContinueOnError)
+	first := fs.String("first-name", e.FirstName, "first name")
+	last := fs.String("last-name", e.LastName, "last name")
+	position := fs.String("position", "", "position id")
+	manager := fs.String("manager", "", "manager id, \"none\" to clear")
+	fs.StringVar(&e.Email, "email", e.Email, "work email")
+	fs.StringVar(&e.Phone, "phone", e.Phone, "phone number")
+	fs.StringVar(&e.DateOfBirth, "birth-date", e.DateOfBirth, "date of birth, YYYY-MM-DD")
+	fs.StringVar(&e.HireDate, "hire-date", e.HireDate, "hire date, YYYY-MM-DD")
+	fs.StringVar(&e.EmploymentType, "employment-type", e.EmploymentType, "full_time, part_time or contractor")
+	fs.StringVar(&e.WorkLocation, "location", e.WorkLocation, "work location")
+	fs.StringVar(&e.EmployeeNumber, "number", e.EmployeeNumber, "employee number")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	switch *manager {
+	case "":
+	case "none":
+		e.ManagerID = nil
+	default:
+		id, err := uuid.Parse(*manager)
+		if err != nil {
+			return fmt.Errorf("invalid manager id: %w", err)
+		}
+		e.ManagerID = &id
+	}
+	if *position != "" {
+		id, err := uuid.Parse(*position)
+		if err != nil {
+			return fmt.Errorf("invalid position id: %w", err)
+		}
+		e.PositionID = id
+	}
+	if required && (*first == "" || *last == "" || e.PositionID == uuid.Nil) {
+		return fmt.Errorf("-first-name, -last-name and -position are required")
+	}
+	e.FirstName, e.LastName = *first, *last
+	return nil
+}
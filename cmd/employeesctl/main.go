@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/VTerenya/employees/client"
+)
+
+const (
+	defaultServer = "http://localhost:8080"
+	usage         = `usage: employeesctl [global flags] <command> [args]
+
+commands:
+  positions list|get|create|update|delete
+  employees list|get|create|update|delete
+  export [-file path]
+  import [-file path]
+  profiles list|use|set
+  completion bash|zsh
+
+global flags:
+`
+)
+
+type app struct {
+	ctx      context.Context
+	client   *client.Client
+	printer  printer
+	profiles *profiles
+	stdin    io.Reader
+	stdout   io.Writer
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "employeesctl:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("employeesctl", flag.ContinueOnError)
+	profileName := fs.String("profile", "", "config profile to use")
+	server := fs.String("server", "", "server URL, overrides the profile")
+	token := fs.String("token", "", "JWT bearer token, overrides the profile")
+	apiKey := fs.String("api-key", "", "API key, overrides the profile")
+	format := fs.String("o", formatTable, "output format: table, json or yaml")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("missing command")
+	}
+	ps, err := loadProfiles()
+	if err != nil {
+		return err
+	}
+	p, err := ps.resolve(*profileName)
+	if err != nil {
+		return err
+	}
+	override(&p.Server, *server)
+	override(&p.Token, *token)
+	override(&p.APIKey, *apiKey)
+	var opts []client.Option
+	if p.Token != "" {
+		opts = append(opts, client.WithBearerToken(p.Token))
+	}
+	if p.APIKey != "" {
+		opts = append(opts, client.WithAPIKey(p.APIKey))
+	}
+	a := &app{
+		ctx:      context.Background(),
+		client:   client.New(p.Server, opts...),
+		printer:  printer{out: stdout, format: *format},
+		profiles: ps,
+		stdin:    stdin,
+		stdout:   stdout,
+	}
+	return a.dispatch(fs.Arg(0), fs.Args()[1:])
+}
+
+func override(dst *string, v string) {
+	if v != "" {
+		*dst = v
+	}
+}
+
+func (a *app) dispatch(command string, args []string) error {
+	switch command {
+	case "positions", "position":
+		return a.positions(args)
+	case "employees", "employee":
+		return a.employees(args)
+	case "export":
+		return a.export(args)
+	case "import":
+		return a.importData(args)
+	case "profiles", "profile":
+		return a.profileCmd(args)
+	case "completion":
+		return completion(a.stdout, args)
+	}
+	return fmt.Errorf("unknown command %q", command)
+}
+
+func verb(args []string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("missing subcommand")
+	}
+	return args[0], args[1:], nil
+}
+
+func oneID(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("expected exactly one id")
+	}
+	return args[0], nil
+}
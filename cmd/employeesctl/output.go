@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+type printer struct {
+	out    io.Writer
+	format string
+}
+
+func (p printer) print(v interface{}, t table) error {
+	switch p.format {
+	case formatJSON:
+		enc := json.NewEncoder(p.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case formatYAML:
+		return writeYAML(p.out, v)
+	case formatTable, "":
+		w := tabwriter.NewWriter(p.out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, strings.Join(t.header, "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		return w.Flush()
+	}
+	return fmt.Errorf("unknown output format %q", p.format)
+}
+
+func writeYAML(out io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return err
+	}
+	var b strings.Builder
+	yamlValue(&b, generic, 0, false)
+	_, err = io.WriteString(out, b.String())
+	return err
+}
+
+func yamlValue(b *strings.Builder, v interface{}, indent int, inList bool) {
+	pad := strings.Repeat("  ", indent)
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if len(val) == 0 {
+			b.WriteString("{}\n")
+			return
+		}
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for i, k := range keys {
+			if !(inList && i == 0) {
+				b.WriteString(pad)
+			}
+			b.WriteString(k + ":")
+			yamlChild(b, val[k], indent+1)
+		}
+	case []interface{}:
+		if len(val) == 0 {
+			b.WriteString("[]\n")
+			return
+		}
+		for i, item := range val {
+			if !(inList && i == 0) {
+				b.WriteString(pad)
+			}
+			b.WriteString("- ")
+			if _, ok := item.(map[string]interface{}); ok {
+				yamlValue(b, item, indent+1, true)
+				continue
+			}
+			if _, ok := item.([]interface{}); ok {
+				yamlValue(b, item, indent+1, true)
+				continue
+			}
+			b.WriteString(yamlScalar(item) + "\n")
+		}
+	default:
+		b.WriteString(yamlScalar(val) + "\n")
+	}
+}
+
+func yamlChild(b *strings.Builder, v interface{}, indent int) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if len(val) == 0 {
+			b.WriteString(" {}\n")
+			return
+		}
+		b.WriteString("\n")
+		yamlValue(b, val, indent, false)
+	case []interface{}:
+		if len(val) == 0 {
+			b.WriteString(" []\n")
+			return
+		}
+		b.WriteString("\n")
+		yamlValue(b, val, indent, false)
+	default:
+		b.WriteString(" " + yamlScalar(val) + "\n")
+	}
+}
+
+func yamlScalar(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case string:
+		return strconv.Quote(val)
+	}
+	return fmt.Sprint(v)
+}
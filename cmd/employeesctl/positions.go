@@ -0,0 +1,115 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/VTerenya/employees/client"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func positionTable(positions ...client.Position) table {
+	t := table{header: []string{"ID", "NAME", "SALARY", "CURRENCY"}}
+	for _, p := range positions {
+		t.rows = append(t.rows, []string{p.ID.String(), p.Name, p.Salary.String(), p.Currency})
+	}
+	return t
+}
+
+func (a *app) listPositions() ([]client.Position, error) {
+	positions := make([]client.Position, 0)
+	it := a.client.Positions(0)
+	for it.Next(a.ctx) {
+		positions = append(positions, it.Position())
+	}
+	return positions, it.Err()
+}
+
+func (a *app) positions(args []string) error {
+	v, rest, err := verb(args)
+	if err != nil {
+		return err
+	}
+	switch v {
+	case "list":
+		positions, err := a.listPositions()
+		if err != nil {
+			return err
+		}
+		return a.printer.print(positions, positionTable(positions...))
+	case "get":
+		id, err := oneID(rest)
+		if err != nil {
+			return err
+		}
+		p, err := a.client.GetPosition(a.ctx, id)
+		if err != nil {
+			return err
+		}
+		return a.printer.print(p, positionTable(p))
+	case "create":
+		var p client.Position
+		if err := positionFlags(&p, rest, true); err != nil {
+			return err
+		}
+		if err := a.client.CreatePosition(a.ctx, &p); err != nil {
+			return err
+		}
+		return a.printer.print(p, positionTable(p))
+	case "update":
+		if len(rest) == 0 {
+			return fmt.Errorf("expected an id")
+		}
+		p, err := a.client.GetPosition(a.ctx, rest[0])
+		if err != nil {
+			return err
+		}
+		if err := positionFlags(&p, rest[1:], false); err != nil {
+			return err
+		}
+		if err := a.client.UpdatePosition(a.ctx, &p); err != nil {
+			return err
+		}
+		return a.printer.print(p, positionTable(p))
+	case "delete":
+		id, err := oneID(rest)
+		if err != nil {
+			return err
+		}
+		return a.client.DeletePosition(a.ctx, id)
+	}
+	return fmt.Errorf("unknown positions subcommand %q", v)
+}
+
+func positionFlags(p *client.Position, args []string, required bool) error {
+	fs := flag.NewFlagSet("position", flag.ContinueOnError)
+	name := fs.String("name", p.Name, "position name")
+	salary := fs.String("salary", p.Salary.String(), "position salary")
+	currency := fs.String("currency", p.Currency, "ISO 4217 currency code of the salary")
+	grade := fs.String("pay-grade", "", "pay grade id, \"none\" to unlink")
+	reason := fs.String("override-reason", p.SalaryOverrideReason, "reason for a salary outside the pay grade band")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if required && (*name == "" || *salary == "0") {
+		return fmt.Errorf("-name and -salary are required")
+	}
+	s, err := decimal.NewFromString(*salary)
+	if err != nil {
+		return fmt.Errorf("invalid salary: %w", err)
+	}
+	p.Name, p.Salary, p.Currency, p.SalaryOverrideReason = *name, s, *currency, *reason
+	switch *grade {
+	case "":
+	case "none":
+		p.PayGradeID = nil
+	default:
+		id, err := uuid.Parse(*grade)
+		if err != nil {
+			return fmt.Errorf("invalid pay grade id: %w", err)
+		}
+		p.PayGradeID = &id
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const envConfig = "EMPLOYEESCTL_CONFIG"
+
+type profile struct {
+	Server string `json:"server"`
+	Token  string `json:"token,omitempty"`
+	APIKey string `json:"api_key,omitempty"`
+}
+
+type profiles struct {
+	Current  string             `json:"current"`
+	Profiles map[string]profile `json:"profiles"`
+}
+
+func configPath() (string, error) {
+	if p := os.Getenv(envConfig); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "employeesctl", "config.json"), nil
+}
+
+func loadProfiles() (*profiles, error) {
+	ps := &profiles{Profiles: map[string]profile{}}
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ps, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, ps); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if ps.Profiles == nil {
+		ps.Profiles = map[string]profile{}
+	}
+	return ps, nil
+}
+
+func (ps *profiles) save() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(ps, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+func (ps *profiles) resolve(name string) (profile, error) {
+	if name == "" {
+		name = ps.Current
+	}
+	if name == "" {
+		return profile{Server: defaultServer}, nil
+	}
+	p, ok := ps.Profiles[name]
+	if !ok {
+		return profile{}, fmt.Errorf("unknown profile %q", name)
+	}
+	return p, nil
+}
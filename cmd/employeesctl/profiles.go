@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+)
+
+func (a *app) profileCmd(args []string) error {
+	v, rest, err := verb(args)
+	if err != nil {
+		return err
+	}
+	switch v {
+	case "list":
+		names := make([]string, 0, len(a.profiles.Profiles))
+		for name := range a.profiles.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		t := table{header: []string{"CURRENT", "NAME", "SERVER"}}
+		for _, name := range names {
+			current := ""
+			if name == a.profiles.Current {
+				current = "*"
+			}
+			t.rows = append(t.rows, []string{current, name, a.profiles.Profiles[name].Server})
+		}
+		return a.printer.print(a.profiles, t)
+	case "use":
+		name, err := oneID(rest)
+		if err != nil {
+			return err
+		}
+		if _, ok := a.profiles.Profiles[name]; !ok {
+			return fmt.Errorf("unknown profile %q", name)
+		}
+		a.profiles.Current = name
+		return a.profiles.save()
+	case "set":
+		if len(rest) == 0 {
+			return fmt.Errorf("expected a profile name")
+		}
+		name := rest[0]
+		p := a.profiles.Profiles[name]
+		fs := flag.NewFlagSet("profile", flag.ContinueOnError)
+		fs.StringVar(&p.Server, "server", p.Server, "server URL")
+		fs.StringVar(&p.Token, "token", p.Token, "JWT bearer token")
+		fs.StringVar(&p.APIKey, "api-key", p.APIKey, "API key")
+		if err := fs.Parse(rest[1:]); err != nil {
+			return err
+		}
+		if p.Server == "" {
+			p.Server = defaultServer
+		}
+		a.profiles.Profiles[name] = p
+		if a.profiles.Current == "" {
+			a.profiles.Current = name
+		}
+		return a.profiles.save()
+	}
+	return fmt.Errorf("unknown profiles subcommand %q", v)
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/VTerenya/employees/client"
+	"github.com/google/uuid"
+)
+
+type dump struct {
+	Attributes  []client.AttributeDefinition `json:"attributes,omitempty"`
+	Departments []client.Department          `json:"departments,omitempty"`
+	Rates       []client.ExchangeRate        `json:"exchange_rates,omitempty"`
+	PayGrades   []client.PayGrade            `json:"pay_grades,omitempty"`
+	Positions   []client.Position            `json:"positions"`
+	Employees   []client.Employee            `json:"employees"`
+	Events      []client.LifecycleEvent      `json:"events,omitempty"`
+}
+
+func fileFlag(name string, args []string) (string, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	file := fs.String("file", "", "file path, standard streams when empty")
+	if err := fs.Parse(args); err != nil {
+		return "", err
+	}
+	return *file, nil
+}
+
+func (a *app) export(args []string) error {
+	file, err := fileFlag("export", args)
+	if err != nil {
+		return err
+	}
+	var d dump
+	if d.Attributes, err = a.client.GetAttributeDefinitions(a.ctx, ""); err != nil {
+		return err
+	}
+	if d.Departments, err = a.listDepartments(); err != nil {
+		return err
+	}
+	if d.Rates, err = a.client.GetExchangeRates(a.ctx, ""); err != nil {
+		return err
+	}
+	// Without salary:read positions come back with zero salaries that
+	// the server refuses on import.
+	d.PayGrades, err = a.client.GetPayGrades(a.ctx)
+	if errors.Is(err, client.ErrForbidden) {
+		return fmt.Errorf("export needs the salary:read permission: %w", err)
+	}
+	if err != nil {
+		return err
+	}
+	if d.Positions, err = a.listPositions(); err != nil {
+		return err
+	}
+	if d.Employees, err = a.listEmployees(); err != nil {
+		return err
+	}
+	for _, e := range d.Employees {
+		events, err := a.client.GetEmployeeEvents(a.ctx, e.ID.String())
+		if err != nil {
+			return fmt.Errorf("events of employee %s: %w", e.ID, err)
+		}
+		d.Events = append(d.Events, events...)
+	}
+	out := a.stdout
+	if file != "" {
+		f, err := os.Create(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close() // nolint: errcheck
+		out = f
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
+
+func (a *app) importData(args []string) error {
+	file, err := fileFlag("import", args)
+	if err != nil {
+		return err
+	}
+	in := a.stdin
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close() // nolint: errcheck
+		in = f
+	}
+	var d dump
+	if err := json.NewDecoder(in).Decode(&d); err != nil && err != io.EOF {
+		return err
+	}
+	if err := d.validate(); err != nil {
+		return fmt.Errorf("nothing imported: %w", err)
+	}
+	var done imported
+	if err := a.load(d, &done); err != nil {
+		return fmt.Errorf("import stopped after %s: %w", done, err)
+	}
+	fmt.Fprintf(a.stdout, "imported %s\n", done)
+	return nil
+}
+
+// imported counts the records created so far, so that a failed import
+// reports exactly what is already on the server.
+type imported struct {
+	attributes, departments, rates, grades, positions, employees, events, managers int
+}
+
+func (i imported) String() string {
+	return fmt.Sprintf("%d attributes, %d departments, %d exchange rates, %d pay grades, "+
+		"%d positions, %d employees, %d lifecycle events and %d manager links",
+		i.attributes, i.departments, i.rates, i.grades, i.positions, i.employees, i.events, i.managers)
+}
+
+// validate checks the whole dump up front against the rules the server
+// enforces, so that a bad dump fails before anything is written.
+func (d dump) validate() error {
+	departments := make(map[string]bool, len(d.Departments))
+	for _, dep := range d.Departments {
+		name := strings.TrimSpace(dep.Name)
+		if name == "" {
+			return fmt.Errorf("department %s: empty name", dep.ID)
+		}
+		if departments[name] {
+			return fmt.Errorf("department %q: duplicate name", name)
+		}
+		departments[name] = true
+	}
+	for _, g := range d.PayGrades {
+		if strings.TrimSpace(g.Name) == "" {
+			return fmt.Errorf("pay grade %s: empty name", g.ID)
+		}
+	}
+	for _, p := range d.Positions {
+		if p.Name == "" {
+			return fmt.Errorf("position %s: empty name", p.ID)
+		}
+		if p.Salary.IsZero() {
+			return fmt.Errorf("position %q: zero salary, export with salary:read", p.Name)
+		}
+	}
+	names := make(map[[2]string]bool, len(d.Employees))
+	ids := make(map[uuid.UUID]bool, len(d.Employees))
+	for _, e := range d.Employees {
+		if e.ID == uuid.Nil || ids[e.ID] {
+			return fmt.Errorf("employee %q %q: missing or duplicate id", e.FirstName, e.LastName)
+		}
+		ids[e.ID] = true
+		if e.FirstName == "" || e.LastName == "" || e.PositionID == uuid.Nil {
+			return fmt.Errorf("employee %s: name and position are required", e.ID)
+		}
+		name := [2]string{e.FirstName, e.LastName}
+		if names[name] {
+			return fmt.Errorf("employee %q %q: duplicate name", e.FirstName, e.LastName)
+		}
+		names[name] = true
+	}
+	for _, event := range d.Events {
+		if !ids[event.EmployeeID] {
+			return fmt.Errorf("event %s: unknown employee %s", event.ID, event.EmployeeID)
+		}
+	}
+	return nil
+}
+
+func (a *app) load(d dump, done *imported) error {
+	for i := range d.Attributes {
+		def := d.Attributes[i]
+		err := a.client.CreateAttributeDefinition(a.ctx, &def)
+		if err != nil && !errors.Is(err, client.ErrAttributeIsExists) {
+			return fmt.Errorf("attribute %s.%s: %w", def.Entity, def.Name, err)
+		}
+		done.attributes++
+	}
+	ids := map[uuid.UUID]uuid.UUID{}
+	for i := range d.Departments {
+		dep := d.Departments[i]
+		old := dep.ID
+		if err := a.client.CreateDepartment(a.ctx, &dep); err != nil {
+			return fmt.Errorf("department %q: %w", dep.Name, err)
+		}
+		ids[old] = dep.ID
+		done.departments++
+	}
+	for i := range d.Rates {
+		rate := d.Rates[i]
+		if err := a.client.SetExchangeRate(a.ctx, &rate); err != nil {
+			return fmt.Errorf("exchange rate %s %s: %w", rate.Currency, rate.EffectiveDate, err)
+		}
+		done.rates++
+	}
+	for i := range d.PayGrades {
+		g := d.PayGrades[i]
+		old := g.ID
+		if err := a.client.CreatePayGrade(a.ctx, &g); err != nil {
+			return fmt.Errorf("pay grade %q: %w", g.Name, err)
+		}
+		ids[old] = g.ID
+		done.grades++
+	}
+	for i := range d.Positions {
+		p := d.Positions[i]
+		old := p.ID
+		if p.DepartmentID != nil {
+			if id, ok := ids[*p.DepartmentID]; ok {
+				p.DepartmentID = &id
+			}
+		}
+		if p.PayGradeID != nil {
+			if id, ok := ids[*p.PayGradeID]; ok {
+				p.PayGradeID = &id
+			}
+		}
+		if err := a.client.CreatePosition(a.ctx, &p); err != nil {
+			return fmt.Errorf("position %q: %w", p.Name, err)
+		}
+		ids[old] = p.ID
+		done.positions++
+	}
+	events := make(map[uuid.UUID][]client.LifecycleEvent, len(d.Employees))
+	for _, event := range d.Events {
+		if event.FromPositionID != nil {
+			if id, ok := ids[*event.FromPositionID]; ok {
+				event.FromPositionID = &id
+			}
+		}
+		if event.ToPositionID != nil {
+			if id, ok := ids[*event.ToPositionID]; ok {
+				event.ToPositionID = &id
+			}
+		}
+		events[event.EmployeeID] = append(events[event.EmployeeID], event)
+	}
+	// Employees keep their ids, status and history; managers are linked once
+	// every employee exists.
+	managed := make([]client.Employee, 0)
+	for i := range d.Employees {
+		rec := client.EmployeeRecord{Employee: d.Employees[i], Events: events[d.Employees[i].ID]}
+		e := &rec.Employee
+		if id, ok := ids[e.PositionID]; ok {
+			e.PositionID = id
+		}
+		managerID := e.ManagerID
+		e.ManagerID = nil
+		if err := a.client.RestoreEmployee(a.ctx, &rec); err != nil {
+			return fmt.Errorf("employee %q %q: %w", e.FirstName, e.LastName, err)
+		}
+		done.employees++
+		done.events += len(rec.Events)
+		if managerID != nil {
+			e.ManagerID = managerID
+			managed = append(managed, *e)
+		}
+	}
+	for i := range managed {
+		e := managed[i]
+		if err := a.client.UpdateEmployee(a.ctx, &e); err != nil {
+			return fmt.Errorf("manager of employee %q %q: %w", e.FirstName, e.LastName, err)
+		}
+		done.managers++
+	}
+	return nil
+}
+
+func (a *app) listDepartments() ([]client.Department, error) {
+	departments := make([]client.Department, 0)
+	it := a.client.Departments(0)
+	for it.Next(a.ctx) {
+		departments = append(departments, it.Department())
+	}
+	return departments, it.Err()
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/VTerenya/employees/client"
+	"github.com/VTerenya/employees/internal/handler"
+	"github.com/VTerenya/employees/internal/middleware"
+	"github.com/VTerenya/employees/internal/repository"
+	"github.com/VTerenya/employees/internal/service"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/shopspring/decimal"
+)
+
+func newTestApp(t *testing.T) *app {
+	t.Helper()
+	h := handler.NewHandler(service.NewServ(repository.NewRepo(repository.NewDataBase()), ""))
+	page := []string{"limit", "{limit:\\S+}", "offset", "{offset:\\S+}"}
+	r := mux.NewRouter()
+	r.HandleFunc("/positions", h.GetPositions).Queries(page...).Methods("GET")
+	r.HandleFunc("/employees", h.GetEmployees).Queries(page...).Methods("GET")
+	r.HandleFunc("/departments", h.GetDepartments).Queries(page...).Methods("GET")
+	r.HandleFunc("/paygrades", h.GetPayGrades).Methods("GET")
+	r.HandleFunc("/rates", h.GetExchangeRates).Methods("GET")
+	r.HandleFunc("/attributes", h.GetAttributeDefinitions).Methods("GET")
+	r.HandleFunc("/employee/hire", h.HireEmployee).Methods("POST")
+	r.HandleFunc("/employee/restore", h.RestoreEmployee).Methods("POST")
+	r.HandleFunc("/employee/{id}/events", h.GetEmployeeEvents).Methods("GET")
+	r.HandleFunc("/employee/{id}/transfer", h.TransferEmployee).Methods("POST")
+	r.HandleFunc("/employee/{id}/terminate", h.TerminateEmployee).Methods("POST")
+	r.HandleFunc("/employee", h.UpdateEmployee).Methods("PUT")
+	r.HandleFunc("/position", h.CreatePosition).Methods("POST")
+	r.HandleFunc("/department", h.CreateDepartment).Methods("POST")
+	r.HandleFunc("/paygrade", h.CreatePayGrade).Methods("POST")
+	r.HandleFunc("/rate", h.SetExchangeRate).Methods("POST")
+	r.HandleFunc("/attribute", h.CreateAttributeDefinition).Methods("POST")
+	r.Use(middleware.IDMiddleware, middleware.NewAnonymousMiddleware())
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return &app{
+		ctx:    context.Background(),
+		client: client.New(srv.URL),
+		stdout: &bytes.Buffer{},
+	}
+}
+
+func seedOrganisation(t *testing.T, a *app) {
+	t.Helper()
+	d := client.Department{Name: "engineering"}
+	if err := a.client.CreateDepartment(a.ctx, &d); err != nil {
+		t.Fatal(err)
+	}
+	dev := client.Position{Name: "dev", Salary: decimal.NewFromInt(100), DepartmentID: &d.ID}
+	lead := client.Position{Name: "lead", Salary: decimal.NewFromInt(200), DepartmentID: &d.ID}
+	for _, p := range []*client.Position{&dev, &lead} {
+		if err := a.client.CreatePosition(a.ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hire := func(first string, manager *uuid.UUID) client.Employee {
+		e := client.Employee{FirstName: first, LastName: "doe", PositionID: dev.ID, ManagerID: manager}
+		if err := a.client.HireEmployee(a.ctx, &e, ""); err != nil {
+			t.Fatal(err)
+		}
+		return e
+	}
+	boss := hire("boss", nil)
+	hire("ann", &boss.ID)
+	leaver := hire("bob", &boss.ID)
+	if _, err := a.client.TransferEmployee(a.ctx, boss.ID.String(), client.LifecycleChange{PositionID: &lead.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.client.TerminateEmployee(a.ctx, leaver.ID.String(), client.LifecycleChange{Reason: "moved"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func exportDump(t *testing.T, a *app) (dump, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "dump.json")
+	if err := a.export([]string{"-file", path}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d dump
+	if err := json.Unmarshal(data, &d); err != nil {
+		t.Fatal(err)
+	}
+	return d, path
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	source := newTestApp(t)
+	seedOrganisation(t, source)
+	before, path := exportDump(t, source)
+
+	target := newTestApp(t)
+	if err := target.importData([]string{"-file", path}); err != nil {
+		t.Fatal(err)
+	}
+	after, _ := exportDump(t, target)
+
+	positionNames := func(d dump) map[uuid.UUID]string {
+		names := make(map[uuid.UUID]string, len(d.Positions))
+		for _, p := range d.Positions {
+			names[p.ID] = p.Name
+		}
+		return names
+	}
+	beforePositions, afterPositions := positionNames(before), positionNames(after)
+	if len(after.Positions) != len(before.Positions) || len(after.Departments) != len(before.Departments) {
+		t.Fatalf("imported %d positions and %d departments, exported %d and %d",
+			len(after.Positions), len(after.Departments), len(before.Positions), len(before.Departments))
+	}
+	if len(after.Employees) != len(before.Employees) || len(before.Employees) != 3 {
+		t.Fatalf("imported %d employees, exported %d", len(after.Employees), len(before.Employees))
+	}
+	imported := make(map[uuid.UUID]client.Employee, len(after.Employees))
+	for _, e := range after.Employees {
+		imported[e.ID] = e
+	}
+	for _, want := range before.Employees {
+		got, ok := imported[want.ID]
+		if !ok {
+			t.Fatalf("employee %s %s lost its id %s", want.FirstName, want.LastName, want.ID)
+		}
+		if got.Status != want.Status {
+			t.Errorf("%s: status %q, want %q", want.FirstName, got.Status, want.Status)
+		}
+		if afterPositions[got.PositionID] != beforePositions[want.PositionID] {
+			t.Errorf("%s: position %q, want %q", want.FirstName,
+				afterPositions[got.PositionID], beforePositions[want.PositionID])
+		}
+		if (got.ManagerID == nil) != (want.ManagerID == nil) ||
+			got.ManagerID != nil && *got.ManagerID != *want.ManagerID {
+			t.Errorf("%s: manager %v, want %v", want.FirstName, got.ManagerID, want.ManagerID)
+		}
+		if got.HireDate != want.HireDate {
+			t.Errorf("%s: hire date %q, want %q", want.FirstName, got.HireDate, want.HireDate)
+		}
+	}
+	if len(after.Events) != len(before.Events) {
+		t.Fatalf("imported %d events, exported %d", len(after.Events), len(before.Events))
+	}
+	for i, want := range before.Events {
+		got := after.Events[i]
+		if got.EmployeeID != want.EmployeeID || got.Type != want.Type || got.EffectiveDate != want.EffectiveDate ||
+			got.Reason != want.Reason {
+			t.Errorf("event %d: %+v, want %+v", i, got, want)
+		}
+		if want.ToPositionID != nil &&
+			(got.ToPositionID == nil || afterPositions[*got.ToPositionID] != beforePositions[*want.ToPositionID]) {
+			t.Errorf("event %d: moved to %v, want %q", i, got.ToPositionID, beforePositions[*want.ToPositionID])
+		}
+	}
+	var terminated int
+	for _, e := range after.Employees {
+		if e.Status == "terminated" {
+			terminated++
+		}
+	}
+	if terminated != 1 {
+		t.Fatalf("%d terminated employees after import, want 1", terminated)
+	}
+}
+
+func TestImportRefusesEventsOfUnknownEmployees(t *testing.T) {
+	source := newTestApp(t)
+	seedOrganisation(t, source)
+	d, _ := exportDump(t, source)
+	d.Events = append(d.Events, client.LifecycleEvent{ID: uuid.New(), EmployeeID: uuid.New(), Type: "hire"})
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := newTestApp(t)
+	target.stdin = bytes.NewReader(data)
+	if err := target.importData(nil); err == nil {
+		t.Fatal("dump with a dangling event imported")
+	}
+	if got, err := target.client.GetPositions(target.ctx, 10, 1); err == nil && len(got) > 0 {
+		t.Fatalf("rejected dump wrote %d positions", len(got))
+	}
+}
@@ -1,11 +1,19 @@
 package main
 
 import (
+	"expvar"
 	"log"
 	"net/http"
 
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/config"
+	"github.com/VTerenya/employees/internal/graphqlapi"
+	"github.com/VTerenya/employees/internal/grpcserver"
 	"github.com/VTerenya/employees/internal/handler"
 	"github.com/VTerenya/employees/internal/middleware"
+	"github.com/VTerenya/employees/internal/openapi"
+	"github.com/VTerenya/employees/internal/ratelimit"
 	"github.com/VTerenya/employees/internal/repository"
 	"github.com/VTerenya/employees/internal/service"
 	"github.com/gorilla/mux"
@@ -23,43 +31,231 @@ type Handler interface { // nolint: deadcode
 	DeleteEmployee(w http.ResponseWriter, r *http.Request)
 	UpdatePosition(w http.ResponseWriter, r *http.Request)
 	UpdateEmployee(w http.ResponseWriter, r *http.Request)
+	GetAPIKeys(w http.ResponseWriter, r *http.Request)
+	CreateAPIKey(w http.ResponseWriter, r *http.Request)
+	RotateAPIKey(w http.ResponseWriter, r *http.Request)
+	RevokeAPIKey(w http.ResponseWriter, r *http.Request)
+	GetDirectReports(w http.ResponseWriter, r *http.Request)
+	GetManagementChain(w http.ResponseWriter, r *http.Request)
+	GetReportingSubtree(w http.ResponseWriter, r *http.Request)
+	GetOrgChart(w http.ResponseWriter, r *http.Request)
+	HireEmployee(w http.ResponseWriter, r *http.Request)
+	RestoreEmployee(w http.ResponseWriter, r *http.Request)
+	TransferEmployee(w http.ResponseWriter, r *http.Request)
+	StartLeave(w http.ResponseWriter, r *http.Request)
+	ReturnFromLeave(w http.ResponseWriter, r *http.Request)
+	TerminateEmployee(w http.ResponseWriter, r *http.Request)
+	RehireEmployee(w http.ResponseWriter, r *http.Request)
+	GetEmployeeEvents(w http.ResponseWriter, r *http.Request)
+	GetDepartments(w http.ResponseWriter, r *http.Request)
+	GetDepartment(w http.ResponseWriter, r *http.Request)
+	GetDepartmentEmployees(w http.ResponseWriter, r *http.Request)
+	CreateDepartment(w http.ResponseWriter, r *http.Request)
+	UpdateDepartment(w http.ResponseWriter, r *http.Request)
+	DeleteDepartment(w http.ResponseWriter, r *http.Request)
+	GetPayGrades(w http.ResponseWriter, r *http.Request)
+	GetPayGrade(w http.ResponseWriter, r *http.Request)
+	CreatePayGrade(w http.ResponseWriter, r *http.Request)
+	UpdatePayGrade(w http.ResponseWriter, r *http.Request)
+	DeletePayGrade(w http.ResponseWriter, r *http.Request)
+	GetCompaRatios(w http.ResponseWriter, r *http.Request)
+	GetPayrollReport(w http.ResponseWriter, r *http.Request)
+	GetHeadcountReport(w http.ResponseWriter, r *http.Request)
+	GetTurnoverReport(w http.ResponseWriter, r *http.Request)
+	GetExchangeRates(w http.ResponseWriter, r *http.Request)
+	SetExchangeRate(w http.ResponseWriter, r *http.Request)
+	DeleteExchangeRate(w http.ResponseWriter, r *http.Request)
+	GetAttributeDefinitions(w http.ResponseWriter, r *http.Request)
+	CreateAttributeDefinition(w http.ResponseWriter, r *http.Request)
+	UpdateAttributeDefinition(w http.ResponseWriter, r *http.Request)
+	DeleteAttributeDefinition(w http.ResponseWriter, r *http.Request)
 }
 
 const (
-	pathPositions  = "/positions"
-	pathEmployees  = "/employees"
-	pathPosition   = "/position"
-	pathEmployee   = "/employee"
-	pathPositionID = "/position/{id:\\S+}"
-	pathEmployeeID = "/employee/{id:\\S+}"
+	pathPositions           = "/positions"
+	pathEmployees           = "/employees"
+	pathPosition            = "/position"
+	pathEmployee            = "/employee"
+	pathPositionID          = "/position/{id:\\S+}"
+	pathEmployeeID          = "/employee/{id:\\S+}"
+	pathEmployeeReports     = "/employee/{id:\\S+}/reports"
+	pathEmployeeChain       = "/employee/{id:\\S+}/chain"
+	pathEmployeeSubtree     = "/employee/{id:\\S+}/subtree"
+	pathEmployeeHire        = "/employee/hire"
+	pathEmployeeRestore     = "/employee/restore"
+	pathEmployeeTransfer    = "/employee/{id:\\S+}/transfer"
+	pathEmployeeLeave       = "/employee/{id:\\S+}/leave"
+	pathEmployeeReturn      = "/employee/{id:\\S+}/return"
+	pathEmployeeTerminate   = "/employee/{id:\\S+}/terminate"
+	pathEmployeeRehire      = "/employee/{id:\\S+}/rehire"
+	pathEmployeeEvents      = "/employee/{id:\\S+}/events"
+	pathOrgChart            = "/orgchart"
+	pathDepartments         = "/departments"
+	pathDepartment          = "/department"
+	pathDepartmentID        = "/department/{id:\\S+}"
+	pathDepartmentEmployees = "/department/{id:\\S+}/employees"
+	pathPayGrades           = "/paygrades"
+	pathPayGrade            = "/paygrade"
+	pathPayGradeID          = "/paygrade/{id:\\S+}"
+	pathCompaRatio          = "/reports/compa-ratio"
+	pathPayroll             = "/reports/payroll"
+	pathHeadcount           = "/reports/headcount"
+	pathTurnover            = "/reports/turnover"
+	pathRates               = "/rates"
+	pathRate                = "/rate"
+	pathRateID              = "/rate/{id:\\S+}"
+	pathAttributes          = "/attributes"
+	pathAttribute           = "/attribute"
+	pathAttributeID         = "/attribute/{id:\\S+}"
+	pathMetrics             = "/debug/vars"
+	pathAPIKeys             = "/apikeys"
+	pathAPIKey              = "/apikey"
+	pathAPIKeyID            = "/apikey/{id:\\S+}"
+	pathAPIKeyRotate        = "/apikey/{id:\\S+}/rotate"
+	pathOpenAPI             = "/openapi.json"
+	pathDocs                = "/docs/"
+	pathGraphQL             = "/graphql"
 )
 
 func Run() {
 	logrus.SetFormatter(&logrus.JSONFormatter{})
-	r := mux.NewRouter()
+	cfg, err := config.FromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+	level, err := logrus.ParseLevel(cfg.Log.Level)
+	if err != nil {
+		log.Fatal(err)
+	}
+	logrus.SetLevel(level)
 	myData := repository.NewDataBase()
 	myRepo := repository.NewRepo(myData)
-	myServ := service.NewServ(myRepo)
+	if !internal.ValidCurrency(cfg.Currency.Base) {
+		log.Fatalf("unknown base currency %q", cfg.Currency.Base)
+	}
+	myServ := service.NewServ(myRepo, cfg.Currency.Base)
+	if cfg.Currency.RatesFile != "" {
+		err = myServ.LoadExchangeRates(cfg.Currency.RatesFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
 	myH := handler.NewHandler(myServ)
-	pathLimit := "{limit:\\S+}"
-	pathOffset := "{offset:\\S+}"
-	r.HandleFunc(pathPositions, myH.GetPositions).Queries("limit", pathLimit, "offset", pathOffset).Methods("GET")
-	r.HandleFunc(pathEmployees, myH.GetEmployees).Queries("limit", pathLimit, "offset", pathOffset).Methods("GET")
-	r.HandleFunc(pathPositionID, myH.GetPosition).Methods("GET")
-	r.HandleFunc(pathEmployeeID, myH.GetEmployee).Methods("GET")
-	r.HandleFunc(pathPositionID, myH.DeletePosition).Methods("DELETE")
-	r.HandleFunc(pathEmployeeID, myH.DeleteEmployee).Methods("DELETE")
-	r.HandleFunc(pathPosition, myH.UpdatePosition).Methods("PUT")
-	r.HandleFunc(pathEmployee, myH.UpdateEmployee).Methods("PUT")
-	r.HandleFunc(pathPosition, myH.CreatePosition).Methods("POST")
-	r.HandleFunc(pathEmployee, myH.CreateEmployee).Methods("POST")
-	r.Use(middleware.IDMiddleware, middleware.TimeLogMiddleware, middleware.AccessLogMiddleware)
-	err := http.ListenAndServe("localhost:8080", r)
+	policy := auth.NewPolicy(cfg.RBAC.Roles)
+	var verifier *auth.JWTVerifier
+	if cfg.Auth.Enabled {
+		verifier, err = auth.NewJWTVerifierFromConfig(cfg.Auth)
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		logrus.Warn("authentication is disabled")
+	}
+	gql, err := graphqlapi.NewHandler(myServ)
+	if err != nil {
+		log.Fatal(err)
+	}
+	r := mux.NewRouter()
+	r.Use(middleware.IDMiddleware, middleware.NewLogMiddleware(cfg.Log.SampleRate), middleware.RecoveryMiddleware)
+	r.HandleFunc(pathOpenAPI, openapi.Handler).Methods("GET")
+	r.PathPrefix(pathDocs).Handler(openapi.DocsHandler(pathDocs)).Methods("GET")
+	api := r.NewRoute().Subrouter()
+	routes(api, myH, gql)
+	if cfg.TLS.Enabled && cfg.TLS.ClientCAFile != "" {
+		api.Use(middleware.NewClientCertMiddleware(cfg.TLS.ClientRoles, policy))
+	}
+	var limiter ratelimit.Limiter
+	if cfg.Rate.Enabled {
+		limiter = ratelimit.NewMemoryLimiter()
+		api.Use(middleware.NewRateLimitMiddleware(limiter, cfg.Rate.Rules))
+	}
+	api.Use(middleware.NewAPIKeyMiddleware(myServ))
+	if verifier != nil {
+		api.Use(middleware.NewAuthMiddleware(verifier, policy))
+	} else {
+		api.Use(middleware.NewAnonymousMiddleware())
+	}
+	h := middleware.NewSecurityHeadersMiddleware(cfg.Headers)(middleware.NewCORSMiddleware(cfg.CORS)(r))
+	var grpcOpts *grpcserver.Options
+	if cfg.GRPC.Enabled {
+		grpcOpts = &grpcserver.Options{
+			Verifier:    verifier,
+			Policy:      policy,
+			APIKeys:     myServ,
+			ClientRoles: cfg.TLS.ClientRoles,
+			Reflection:  cfg.GRPC.Reflection,
+			Limiter:     limiter,
+			RateRules:   cfg.Rate.Rules,
+		}
+	}
+	err = serve(cfg, h, myServ, grpcOpts)
 	if err != nil {
 		log.Fatal(err)
 	}
 }
 
+func routes(r *mux.Router, myH Handler, gql http.Handler) {
+	pathLimit := "{limit:\\S+}"
+	pathOffset := "{offset:\\S+}"
+	r.Handle(pathPositions, protect(auth.PermPositionsRead, myH.GetPositions)).
+		Queries("limit", pathLimit, "offset", pathOffset).Methods("GET")
+	r.Handle(pathEmployees, protect(auth.PermEmployeesRead, myH.GetEmployees)).
+		Queries("limit", pathLimit, "offset", pathOffset).Methods("GET")
+	r.Handle(pathEmployeeReports, protect(auth.PermEmployeesRead, myH.GetDirectReports)).Methods("GET")
+	r.Handle(pathEmployeeChain, protect(auth.PermEmployeesRead, myH.GetManagementChain)).Methods("GET")
+	r.Handle(pathEmployeeSubtree, protect(auth.PermEmployeesRead, myH.GetReportingSubtree)).Methods("GET")
+	r.Handle(pathEmployeeEvents, protect(auth.PermEmployeesRead, myH.GetEmployeeEvents)).Methods("GET")
+	r.Handle(pathEmployeeHire, protect(auth.PermEmployeesWrite, myH.HireEmployee)).Methods("POST")
+	r.Handle(pathEmployeeRestore, protect(auth.PermEmployeesWrite, myH.RestoreEmployee)).Methods("POST")
+	r.Handle(pathEmployeeTransfer, protect(auth.PermEmployeesWrite, myH.TransferEmployee)).Methods("POST")
+	r.Handle(pathEmployeeLeave, protect(auth.PermEmployeesWrite, myH.StartLeave)).Methods("POST")
+	r.Handle(pathEmployeeReturn, protect(auth.PermEmployeesWrite, myH.ReturnFromLeave)).Methods("POST")
+	r.Handle(pathEmployeeTerminate, protect(auth.PermEmployeesWrite, myH.TerminateEmployee)).Methods("POST")
+	r.Handle(pathEmployeeRehire, protect(auth.PermEmployeesWrite, myH.RehireEmployee)).Methods("POST")
+	r.Handle(pathPositionID, protect(auth.PermPositionsRead, myH.GetPosition)).Methods("GET")
+	r.Handle(pathEmployeeID, protect(auth.PermEmployeesRead, myH.GetEmployee)).Methods("GET")
+	r.Handle(pathPositionID, protect(auth.PermPositionsWrite, myH.DeletePosition)).Methods("DELETE")
+	r.Handle(pathEmployeeID, protect(auth.PermEmployeesWrite, myH.DeleteEmployee)).Methods("DELETE")
+	r.Handle(pathPosition, protect(auth.PermPositionsWrite, myH.UpdatePosition)).Methods("PUT")
+	r.Handle(pathEmployee, protect(auth.PermEmployeesWrite, myH.UpdateEmployee)).Methods("PUT")
+	r.Handle(pathPosition, protect(auth.PermPositionsWrite, myH.CreatePosition)).Methods("POST")
+	r.Handle(pathEmployee, protect(auth.PermEmployeesWrite, myH.CreateEmployee)).Methods("POST")
+	r.Handle(pathOrgChart, protect(auth.PermEmployeesRead, myH.GetOrgChart)).Methods("GET")
+	r.Handle(pathDepartments, protect(auth.PermDepartmentsRead, myH.GetDepartments)).
+		Queries("limit", pathLimit, "offset", pathOffset).Methods("GET")
+	r.Handle(pathDepartmentEmployees, protect(auth.PermDepartmentsRead, myH.GetDepartmentEmployees)).Methods("GET")
+	r.Handle(pathDepartmentID, protect(auth.PermDepartmentsRead, myH.GetDepartment)).Methods("GET")
+	r.Handle(pathDepartmentID, protect(auth.PermDepartmentsWrite, myH.DeleteDepartment)).Methods("DELETE")
+	r.Handle(pathDepartment, protect(auth.PermDepartmentsWrite, myH.UpdateDepartment)).Methods("PUT")
+	r.Handle(pathDepartment, protect(auth.PermDepartmentsWrite, myH.CreateDepartment)).Methods("POST")
+	r.Handle(pathPayGrades, protect(auth.PermSalaryRead, myH.GetPayGrades)).Methods("GET")
+	r.Handle(pathPayGradeID, protect(auth.PermSalaryRead, myH.GetPayGrade)).Methods("GET")
+	r.Handle(pathPayGradeID, protect(auth.PermSalaryWrite, myH.DeletePayGrade)).Methods("DELETE")
+	r.Handle(pathPayGrade, protect(auth.PermSalaryWrite, myH.UpdatePayGrade)).Methods("PUT")
+	r.Handle(pathPayGrade, protect(auth.PermSalaryWrite, myH.CreatePayGrade)).Methods("POST")
+	r.Handle(pathCompaRatio, protect(auth.PermSalaryRead, myH.GetCompaRatios)).Methods("GET")
+	r.Handle(pathPayroll, protect(auth.PermSalaryRead, myH.GetPayrollReport)).Methods("GET")
+	r.Handle(pathHeadcount, protect(auth.PermEmployeesRead, myH.GetHeadcountReport)).Methods("GET")
+	r.Handle(pathTurnover, protect(auth.PermEmployeesRead, myH.GetTurnoverReport)).Methods("GET")
+	r.Handle(pathRates, protect(auth.PermPositionsRead, myH.GetExchangeRates)).Methods("GET")
+	r.Handle(pathRate, protect(auth.PermRatesAdmin, myH.SetExchangeRate)).Methods("POST")
+	r.Handle(pathRateID, protect(auth.PermRatesAdmin, myH.DeleteExchangeRate)).Methods("DELETE")
+	r.Handle(pathAttributes, http.HandlerFunc(myH.GetAttributeDefinitions)).Methods("GET")
+	r.Handle(pathAttribute, protect(auth.PermAttributesAdmin, myH.CreateAttributeDefinition)).Methods("POST")
+	r.Handle(pathAttribute, protect(auth.PermAttributesAdmin, myH.UpdateAttributeDefinition)).Methods("PUT")
+	r.Handle(pathAttributeID, protect(auth.PermAttributesAdmin, myH.DeleteAttributeDefinition)).Methods("DELETE")
+	r.Handle(pathAPIKeys, protect(auth.PermAPIKeysAdmin, myH.GetAPIKeys)).Methods("GET")
+	r.Handle(pathAPIKey, protect(auth.PermAPIKeysAdmin, myH.CreateAPIKey)).Methods("POST")
+	r.Handle(pathAPIKeyRotate, protect(auth.PermAPIKeysAdmin, myH.RotateAPIKey)).Methods("POST")
+	r.Handle(pathAPIKeyID, protect(auth.PermAPIKeysAdmin, myH.RevokeAPIKey)).Methods("DELETE")
+	r.Handle(pathGraphQL, gql).Methods("GET", "POST")
+	r.Handle(pathMetrics, protect(auth.PermMetricsAdmin, expvar.Handler().ServeHTTP)).Methods("GET")
+}
+
+func protect(permission string, h http.HandlerFunc) http.Handler {
+	return middleware.RequirePermission(permission)(h)
+}
+
 func main() {
 	Run()
 }
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/VTerenya/employees/internal/config"
+	"github.com/VTerenya/employees/internal/graphqlapi"
+	"github.com/VTerenya/employees/internal/handler"
+	"github.com/VTerenya/employees/internal/middleware"
+	"github.com/VTerenya/employees/internal/openapi"
+	"github.com/VTerenya/employees/internal/repository"
+	"github.com/VTerenya/employees/internal/service"
+	"github.com/gorilla/mux"
+)
+
+var pathVariable = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`) // nolint: gochecknoglobals
+
+func newTestRouter(t *testing.T) *mux.Router {
+	t.Helper()
+	serv := service.NewServ(repository.NewRepo(repository.NewDataBase()), "")
+	gql, err := graphqlapi.NewHandler(serv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := mux.NewRouter()
+	routes(r, handler.NewHandler(serv), gql)
+	return r
+}
+
+// TestRoutesDocumented fails for every route whose path and method are not
+// described in the embedded OpenAPI document.
+func TestRoutesDocumented(t *testing.T) {
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(openapi.Spec(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	var missing []string
+	err := newTestRouter(t).Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		tpl, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		path := pathVariable.ReplaceAllString(tpl, "{$1}")
+		for _, m := range methods {
+			if _, ok := doc.Paths[path][strings.ToLower(m)]; !ok {
+				missing = append(missing, m+" "+path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(missing)
+	for _, m := range missing {
+		t.Errorf("route missing from the openapi document: %s", m)
+	}
+}
+
+// TestCORSPreflight sends a preflight request for every route and method.
+// The router rejects every other request, so a preflight that reached
+// authentication would fail.
+func TestCORSPreflight(t *testing.T) {
+	const origin = "https://app.example.com"
+	cfg := config.Default().CORS
+	cfg.AllowedOrigins = []string{origin}
+	r := newTestRouter(t)
+	r.Use(func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+	})
+	h := middleware.NewCORSMiddleware(cfg)(r)
+	type preflight struct {
+		path, method, origin string
+		status               int
+	}
+	var tests []preflight
+	err := r.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		tpl, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		path := pathVariable.ReplaceAllString(tpl, "00000000-0000-0000-0000-000000000001")
+		for _, m := range methods {
+			tests = append(tests, preflight{path, m, origin, http.StatusNoContent})
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tests) == 0 {
+		t.Fatal("router has no routes")
+	}
+	tests = append(tests, preflight{"/employee", http.MethodPost, "https://evil.example.com", http.StatusForbidden})
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodOptions, tt.path, nil)
+		req.Header.Set("Origin", tt.origin)
+		req.Header.Set("Access-Control-Request-Method", tt.method)
+		req.Header.Set("Access-Control-Request-Headers", "Authorization, Content-Type")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("%s %s from %s: status %d, want %d", tt.method, tt.path, tt.origin, rec.Code, tt.status)
+			continue
+		}
+		if tt.status != http.StatusNoContent {
+			continue
+		}
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != origin {
+			t.Errorf("%s %s: Access-Control-Allow-Origin = %q", tt.method, tt.path, got)
+		}
+		if got := rec.Header().Get("Access-Control-Allow-Methods"); !strings.Contains(got, tt.method) {
+			t.Errorf("%s %s: Access-Control-Allow-Methods = %q", tt.method, tt.path, got)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/VTerenya/employees/internal/config"
+	"github.com/VTerenya/employees/internal/grpcserver"
+	"github.com/VTerenya/employees/internal/handler"
+	"github.com/VTerenya/employees/internal/tlsconfig"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const readHeaderTimeout = 10 * time.Second
+
+func grpcOrHTTP(grpcSrv *grpc.Server, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			grpcSrv.ServeHTTP(w, r)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+func serve(cfg *config.Config, h http.Handler, service handler.Service, grpcOpts *grpcserver.Options) error {
+	var reloader *tlsconfig.Reloader
+	if cfg.TLS.Enabled {
+		var err error
+		reloader, err = tlsconfig.NewReloader(cfg.TLS)
+		if err != nil {
+			return err
+		}
+		stop := make(chan struct{})
+		defer close(stop)
+		go reloader.Watch(stop)
+	}
+	if grpcOpts != nil {
+		if cfg.GRPC.Addr == "" {
+			h = grpcOrHTTP(grpcserver.New(service, *grpcOpts), h)
+			if reloader == nil {
+				h = h2c.NewHandler(h, &http2.Server{})
+			}
+		} else {
+			var serverOpts []grpc.ServerOption
+			if reloader != nil {
+				serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(reloader.TLSConfig())))
+			}
+			lis, err := net.Listen("tcp", cfg.GRPC.Addr)
+			if err != nil {
+				return err
+			}
+			grpcSrv := grpcserver.New(service, *grpcOpts, serverOpts...)
+			go func() {
+				if err := grpcSrv.Serve(lis); err != nil {
+					logrus.WithError(err).Fatal("grpc server stopped")
+				}
+			}()
+		}
+	}
+	srv := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           h,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	if reloader == nil {
+		return srv.ListenAndServe()
+	}
+	srv.TLSConfig = reloader.TLSConfig()
+	return srv.ListenAndServeTLS("", "")
+}
@@ -0,0 +1,39 @@
+package internal
+
+import "github.com/google/uuid"
+
+const (
+	EntityEmployee = "employee"
+	EntityPosition = "position"
+)
+
+const (
+	AttributeString  = "string"
+	AttributeNumber  = "number"
+	AttributeBoolean = "boolean"
+	AttributeDate    = "date"
+	AttributeEnum    = "enum"
+)
+
+func ValidEntity(entity string) bool {
+	return entity == EntityEmployee || entity == EntityPosition
+}
+
+func ValidAttributeType(t string) bool {
+	switch t {
+	case AttributeString, AttributeNumber, AttributeBoolean, AttributeDate, AttributeEnum:
+		return true
+	}
+	return false
+}
+
+type AttributeDefinition struct {
+	ID            uuid.UUID `json:"id"`
+	Entity        string    `json:"entity"`
+	Name          string    `json:"name"`
+	Type          string    `json:"type"`
+	Required      bool      `json:"required"`
+	AllowedValues []string  `json:"allowed_values,omitempty"`
+}
+
+type Attributes map[string]interface{}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+
+	"github.com/VTerenya/employees/internal"
+)
+
+type Identity struct {
+	Subject     string
+	Method      string
+	Roles       []string
+	Permissions map[string]bool
+}
+
+type contextKey int
+
+const identityContextKey contextKey = iota
+
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityContextKey, id)
+}
+
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityContextKey).(Identity)
+	return id, ok
+}
+
+func Subject(ctx context.Context) string {
+	id, _ := FromContext(ctx)
+	return id.Subject
+}
+
+// Can reports whether the caller may use the permission. Callers without an
+// identity may do nothing; see AnonymousIdentity for disabled authentication.
+func Can(ctx context.Context, permission string) bool {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return false
+	}
+	return id.Permissions[permission]
+}
+
+// AnonymousIdentity is granted every permission. It is only installed for
+// unauthenticated callers when authentication is disabled.
+func AnonymousIdentity() Identity {
+	permissions := make(map[string]bool, len(allPermissions))
+	for _, permission := range allPermissions {
+		permissions[permission] = true
+	}
+	return Identity{
+		Subject:     "anonymous",
+		Method:      "none",
+		Permissions: permissions,
+	}
+}
+
+func JWTIdentity(claims *Claims, policy *Policy) Identity {
+	return Identity{
+		Subject:     claims.Subject,
+		Method:      "jwt",
+		Roles:       claims.Roles,
+		Permissions: policy.Permissions(claims.Roles),
+	}
+}
+
+func APIKeyIdentity(k internal.APIKey) Identity {
+	permissions := make(map[string]bool, len(k.Scopes))
+	for _, scope := range k.Scopes {
+		permissions[scope] = true
+	}
+	return Identity{
+		Subject:     "apikey:" + k.ID.String(),
+		Method:      "apikey",
+		Permissions: permissions,
+	}
+}
+
+// CertificateSubject names a client certificate by its common name, falling
+// back to the first DNS, email or URI subject alternative name and then to
+// the full distinguished name.
+func CertificateSubject(cert *x509.Certificate) string {
+	switch {
+	case cert.Subject.CommonName != "":
+		return cert.Subject.CommonName
+	case len(cert.DNSNames) > 0:
+		return cert.DNSNames[0]
+	case len(cert.EmailAddresses) > 0:
+		return cert.EmailAddresses[0]
+	case len(cert.URIs) > 0:
+		return cert.URIs[0].String()
+	}
+	return cert.Subject.String()
+}
+
+// CertificateIdentity maps a verified client certificate to the roles
+// configured for its subject. A subject without configured roles yields no
+// identity, so the caller may still authenticate another way.
+func CertificateIdentity(cert *x509.Certificate, clientRoles map[string][]string, policy *Policy) (Identity, bool) {
+	subject := CertificateSubject(cert)
+	roles, ok := clientRoles[subject]
+	if !ok {
+		return Identity{}, false
+	}
+	return Identity{
+		Subject:     subject,
+		Method:      "mtls",
+		Roles:       roles,
+		Permissions: policy.Permissions(roles),
+	}, true
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+)
+
+func TestCanFailsClosedWithoutIdentity(t *testing.T) {
+	if Can(context.Background(), PermEmployeesRead) {
+		t.Fatal("a context without identity must not be granted permissions")
+	}
+}
+
+func TestAnonymousIdentityHasEveryPermission(t *testing.T) {
+	ctx := WithIdentity(context.Background(), AnonymousIdentity())
+	for _, permission := range allPermissions {
+		if !Can(ctx, permission) {
+			t.Errorf("anonymous identity is missing %s", permission)
+		}
+	}
+}
+
+func TestCanChecksIdentityPermissions(t *testing.T) {
+	ctx := WithIdentity(context.Background(), Identity{Permissions: map[string]bool{PermPositionsRead: true}})
+	if !Can(ctx, PermPositionsRead) || Can(ctx, PermPositionsWrite) {
+		t.Fatal("permissions do not match the identity")
+	}
+}
+
+func TestCertificateSubject(t *testing.T) {
+	spiffe, _ := url.Parse("spiffe://example.com/payroll")
+	tests := []struct {
+		name string
+		cert x509.Certificate
+		want string
+	}{
+		{"common name", x509.Certificate{Subject: pkix.Name{CommonName: "payroll"}, DNSNames: []string{"dns"}}, "payroll"},
+		{"dns", x509.Certificate{DNSNames: []string{"payroll.example.com"}}, "payroll.example.com"},
+		{"email", x509.Certificate{EmailAddresses: []string{"payroll@example.com"}}, "payroll@example.com"},
+		{"uri", x509.Certificate{URIs: []*url.URL{spiffe}}, "spiffe://example.com/payroll"},
+		{"distinguished name", x509.Certificate{Subject: pkix.Name{Organization: []string{"Example"}}}, "O=Example"},
+	}
+	for _, tt := range tests {
+		if got := CertificateSubject(&tt.cert); got != tt.want {
+			t.Errorf("%s: subject = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCertificateIdentity(t *testing.T) {
+	policy := NewPolicy(map[string][]string{"staff": {PermEmployeesRead}})
+	roles := map[string][]string{"payroll.example.com": {"staff"}}
+	id, ok := CertificateIdentity(&x509.Certificate{DNSNames: []string{"payroll.example.com"}}, roles, policy)
+	if !ok || id.Subject != "payroll.example.com" || id.Method != "mtls" || !id.Permissions[PermEmployeesRead] {
+		t.Fatalf("identity = %+v, %v", id, ok)
+	}
+	if id, ok := CertificateIdentity(&x509.Certificate{Subject: pkix.Name{CommonName: "unknown"}}, roles, policy); ok {
+		t.Fatalf("unknown subject got identity %+v", id)
+	}
+}
@@ -0,0 +1,167 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VTerenya/employees/internal/errors"
+)
+
+const (
+	algHS256 = "HS256"
+	algRS256 = "RS256"
+)
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+type Claims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf"`
+	IssuedAt  int64    `json:"iat"`
+	Roles     []string `json:"roles"`
+}
+
+type JWTVerifier struct {
+	keys     *KeySet
+	issuer   string
+	audience string
+	leeway   time.Duration
+	now      func() time.Time
+}
+
+func NewJWTVerifier(keys *KeySet, issuer, aud string, leeway time.Duration) *JWTVerifier {
+	return &JWTVerifier{
+		keys:     keys,
+		issuer:   issuer,
+		audience: aud,
+		leeway:   leeway,
+		now:      time.Now,
+	}
+}
+
+func invalid(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: "+format, append([]interface{}{errors.Unauthorized()}, args...)...)
+}
+
+func (v *JWTVerifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, invalid("malformed token")
+	}
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, invalid("malformed header")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalid("malformed signature")
+	}
+	if err := v.verifySignature(h, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+	var c Claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return nil, invalid("malformed claims")
+	}
+	if err := v.validateClaims(&c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (v *JWTVerifier) verifySignature(h header, signed string, signature []byte) error {
+	switch h.Alg {
+	case algHS256:
+		key, ok := v.keys.hmacKey(h.Kid)
+		if !ok {
+			return invalid("unknown key %q", h.Kid)
+		}
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(signed)) // nolint: errcheck
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return invalid("invalid signature")
+		}
+	case algRS256:
+		key, ok := v.keys.rsaKey(h.Kid)
+		if !ok {
+			return invalid("unknown key %q", h.Kid)
+		}
+		digest := sha256.Sum256([]byte(signed))
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return invalid("invalid signature")
+		}
+	default:
+		return invalid("unsupported algorithm %q", h.Alg)
+	}
+	return nil
+}
+
+func (v *JWTVerifier) validateClaims(c *Claims) error {
+	now := v.now()
+	if c.Subject == "" {
+		return invalid("missing subject")
+	}
+	if c.ExpiresAt == 0 {
+		return invalid("missing expiration")
+	}
+	if now.After(time.Unix(c.ExpiresAt, 0).Add(v.leeway)) {
+		return invalid("token expired")
+	}
+	if c.NotBefore != 0 && now.Add(v.leeway).Before(time.Unix(c.NotBefore, 0)) {
+		return invalid("token not valid yet")
+	}
+	if v.issuer != "" && c.Issuer != v.issuer {
+		return invalid("unexpected issuer %q", c.Issuer)
+	}
+	if v.audience != "" && !c.Audience.contains(v.audience) {
+		return invalid("unexpected audience")
+	}
+	return nil
+}
+
+func (a audience) contains(s string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeSegment(seg string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
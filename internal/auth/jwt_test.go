@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	errs "errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VTerenya/employees/internal/errors"
+)
+
+type signer struct {
+	alg, kid string
+	hmac     []byte
+	rsa      *rsa.PrivateKey
+}
+
+func (s signer) sign(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+	h, err := json.Marshal(header{Alg: s.alg, Kid: s.kid, Typ: "JWT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+	var signature []byte
+	switch {
+	case s.rsa != nil:
+		digest := sha256.Sum256([]byte(signed))
+		signature, err = rsa.SignPKCS1v15(rand.Reader, s.rsa, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+	case s.hmac != nil:
+		mac := hmac.New(sha256.New, s.hmac)
+		mac.Write([]byte(signed)) // nolint: errcheck
+		signature = mac.Sum(nil)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func writeJWKS(t *testing.T, keys ...map[string]string) string {
+	t.Helper()
+	data, err := json.Marshal(map[string]interface{}{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestJWTVerifierVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	secret := []byte("hmac-secret")
+	rsaKey := newRSAKey(t)
+	otherRSA := newRSAKey(t)
+	jwksRSA := newRSAKey(t)
+	jwksPath := writeJWKS(t,
+		map[string]string{
+			"kty": "RSA", "kid": "rsa-1", "use": "sig",
+			"n": base64.RawURLEncoding.EncodeToString(jwksRSA.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(jwksRSA.E)).Bytes()),
+		},
+		map[string]string{"kty": "oct", "kid": "oct-1", "k": base64.RawURLEncoding.EncodeToString([]byte("jwks-secret"))},
+		map[string]string{"kty": "oct", "kid": "enc-1", "use": "enc", "k": base64.RawURLEncoding.EncodeToString(secret)},
+	)
+
+	hsKeys := NewKeySet()
+	hsKeys.AddHMAC("", secret)
+	rsKeys := NewKeySet()
+	rsKeys.AddRSA("", &rsaKey.PublicKey)
+	jwksKeys := NewKeySet()
+	if err := jwksKeys.LoadJWKSFile(jwksPath); err != nil {
+		t.Fatal(err)
+	}
+
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"sub": "ann", "iss": "issuer", "aud": "employees",
+			"exp": now.Add(time.Hour).Unix(), "iat": now.Unix(), "roles": []string{"viewer"},
+		}
+	}
+	with := func(key string, value interface{}) map[string]interface{} {
+		c := valid()
+		if value == nil {
+			delete(c, key)
+		} else {
+			c[key] = value
+		}
+		return c
+	}
+	hs := signer{alg: algHS256, hmac: secret}
+	rs := signer{alg: algRS256, rsa: rsaKey}
+
+	for _, tt := range []struct {
+		name   string
+		keys   *KeySet
+		signer signer
+		claims map[string]interface{}
+		err    string
+	}{
+		{"HS256", hsKeys, hs, valid(), ""},
+		{"HS256 audience list", hsKeys, hs, with("aud", []string{"other", "employees"}), ""},
+		{"HS256 expired", hsKeys, hs, with("exp", now.Add(-time.Minute).Unix()), "token expired"},
+		{"HS256 expired within leeway", hsKeys, hs, with("exp", now.Add(-10*time.Second).Unix()), ""},
+		{"HS256 missing exp", hsKeys, hs, with("exp", nil), "missing expiration"},
+		{"HS256 before nbf", hsKeys, hs, with("nbf", now.Add(time.Minute).Unix()), "not valid yet"},
+		{"HS256 nbf within leeway", hsKeys, hs, with("nbf", now.Add(10*time.Second).Unix()), ""},
+		{"HS256 bad signature", hsKeys, signer{alg: algHS256, hmac: []byte("other")}, valid(), "invalid signature"},
+		{"HS256 wrong issuer", hsKeys, hs, with("iss", "someone"), "unexpected issuer"},
+		{"HS256 wrong audience", hsKeys, hs, with("aud", "billing"), "unexpected audience"},
+		{"HS256 missing subject", hsKeys, hs, with("sub", nil), "missing subject"},
+		{"HS256 unknown kid", hsKeys, signer{alg: algHS256, kid: "k2", hmac: secret}, valid(), "unknown key"},
+		{"RS256", rsKeys, rs, valid(), ""},
+		{"RS256 bad signature", rsKeys, signer{alg: algRS256, rsa: otherRSA}, valid(), "invalid signature"},
+		{"RS256 expired", rsKeys, rs, with("exp", now.Add(-time.Hour).Unix()), "token expired"},
+		{"RS256 key used as HS256", rsKeys, hs, valid(), "unknown key"},
+		{"alg none", hsKeys, signer{alg: "none"}, valid(), "unsupported algorithm"},
+		{"alg HS512", hsKeys, signer{alg: "HS512", hmac: secret}, valid(), "unsupported algorithm"},
+		{"JWKS RSA", jwksKeys, signer{alg: algRS256, kid: "rsa-1", rsa: jwksRSA}, valid(), ""},
+		{"JWKS oct", jwksKeys, signer{alg: algHS256, kid: "oct-1", hmac: []byte("jwks-secret")}, valid(), ""},
+		{"JWKS unknown kid", jwksKeys, signer{alg: algRS256, kid: "rsa-2", rsa: jwksRSA}, valid(), "unknown key"},
+		{"JWKS missing kid", jwksKeys, signer{alg: algRS256, rsa: jwksRSA}, valid(), ""},
+		{"JWKS encryption key", jwksKeys, signer{alg: algHS256, kid: "enc-1", hmac: secret}, valid(), "unknown key"},
+		{"JWKS kid of another key", jwksKeys, signer{alg: algRS256, kid: "rsa-1", rsa: otherRSA}, valid(),
+			"invalid signature"},
+	} {
+		v := NewJWTVerifier(tt.keys, "issuer", "employees", 30*time.Second)
+		v.now = func() time.Time { return now }
+		claims, err := v.Verify(tt.signer.sign(t, tt.claims))
+		if tt.err == "" {
+			if err != nil {
+				t.Errorf("%s: %v", tt.name, err)
+			} else if claims.Subject != "ann" || len(claims.Roles) != 1 {
+				t.Errorf("%s: claims %+v", tt.name, claims)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: got %v, want %q", tt.name, err, tt.err)
+			continue
+		}
+		if !errs.Is(err, errors.Unauthorized()) {
+			t.Errorf("%s: %v is not unauthorized", tt.name, err)
+		}
+	}
+}
+
+func TestJWTVerifierRejectsMalformedTokens(t *testing.T) {
+	keys := NewKeySet()
+	keys.AddHMAC("", []byte("secret"))
+	v := NewJWTVerifier(keys, "", "", 0)
+	for _, token := range []string{"", "a.b", "a.b.c.d", "!!.e30.", "e30.e30.!!"} {
+		if _, err := v.Verify(token); err == nil {
+			t.Errorf("%q: verified", token)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+type KeySet struct {
+	hmac map[string][]byte
+	rsa  map[string]*rsa.PublicKey
+}
+
+func NewKeySet() *KeySet {
+	return &KeySet{
+		hmac: map[string][]byte{},
+		rsa:  map[string]*rsa.PublicKey{},
+	}
+}
+
+func (k *KeySet) AddHMAC(kid string, secret []byte) {
+	k.hmac[kid] = secret
+}
+
+func (k *KeySet) AddRSA(kid string, key *rsa.PublicKey) {
+	k.rsa[kid] = key
+}
+
+func (k *KeySet) Empty() bool {
+	return len(k.hmac) == 0 && len(k.rsa) == 0
+}
+
+func (k *KeySet) LoadHMACFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	secret := bytes.TrimSpace(data)
+	if len(secret) == 0 {
+		return fmt.Errorf("hmac secret file %s is empty", path)
+	}
+	k.AddHMAC("", secret)
+	return nil
+}
+
+func (k *KeySet) LoadRSAFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	key, err := parseRSAPublicKey(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	k.AddRSA("", key)
+	return nil
+}
+
+func parseRSAPublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("public key is not RSA")
+		}
+		return rsaKey, nil
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		rsaKey, ok := cert.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("certificate key is not RSA")
+		}
+		return rsaKey, nil
+	}
+	return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+func (k *KeySet) LoadJWKSFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		switch key.Kty {
+		case "RSA":
+			pub, err := key.rsaPublicKey()
+			if err != nil {
+				return fmt.Errorf("%s: key %q: %w", path, key.Kid, err)
+			}
+			k.AddRSA(key.Kid, pub)
+		case "oct":
+			secret, err := base64.RawURLEncoding.DecodeString(key.K)
+			if err != nil {
+				return fmt.Errorf("%s: key %q: %w", path, key.Kid, err)
+			}
+			k.AddHMAC(key.Kid, secret)
+		}
+	}
+	return nil
+}
+
+func (j jwk) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(j.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(j.E)
+	if err != nil {
+		return nil, err
+	}
+	exp := new(big.Int).SetBytes(e)
+	if !exp.IsInt64() || exp.Int64() < 3 || exp.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("invalid RSA exponent")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+}
+
+func (k *KeySet) hmacKey(kid string) ([]byte, bool) {
+	if key, ok := k.hmac[kid]; ok {
+		return key, true
+	}
+	if kid != "" || len(k.hmac) != 1 {
+		return nil, false
+	}
+	for _, key := range k.hmac {
+		return key, true
+	}
+	return nil, false
+}
+
+func (k *KeySet) rsaKey(kid string) (*rsa.PublicKey, bool) {
+	if key, ok := k.rsa[kid]; ok {
+		return key, true
+	}
+	if kid != "" || len(k.rsa) != 1 {
+		return nil, false
+	}
+	for _, key := range k.rsa {
+		return key, true
+	}
+	return nil, false
+}
@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/VTerenya/employees/internal/config"
+)
+
+func NewJWTVerifierFromConfig(cfg config.Auth) (*JWTVerifier, error) {
+	keys := NewKeySet()
+	if cfg.HMACSecretFile != "" {
+		if err := keys.LoadHMACFile(cfg.HMACSecretFile); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.RSAPublicKeyFile != "" {
+		if err := keys.LoadRSAFile(cfg.RSAPublicKeyFile); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.JWKSFile != "" {
+		if err := keys.LoadJWKSFile(cfg.JWKSFile); err != nil {
+			return nil, err
+		}
+	}
+	if keys.Empty() {
+		return nil, fmt.Errorf("auth is enabled but no verification keys are configured; " +
+			"set auth.enabled to false to run without authentication")
+	}
+	leeway := time.Duration(cfg.LeewaySeconds) * time.Second
+	return NewJWTVerifier(keys, cfg.Issuer, cfg.Audience, leeway), nil
+}
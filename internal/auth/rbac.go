@@ -0,0 +1,51 @@
+package auth
+
+const (
+	PermEmployeesRead    = "employees:read"
+	PermEmployeesWrite   = "employees:write"
+	PermPositionsRead    = "positions:read"
+	PermPositionsWrite   = "positions:write"
+	PermSalaryRead       = "salary:read"
+	PermSalaryWrite      = "salary:write"
+	PermDepartmentsRead  = "departments:read"
+	PermDepartmentsWrite = "departments:write"
+	PermAPIKeysAdmin     = "apikeys:admin"
+	PermAttributesAdmin  = "attributes:admin"
+	PermRatesAdmin       = "rates:admin"
+	PermMetricsAdmin     = "metrics:admin"
+)
+
+var allPermissions = []string{ // nolint: gochecknoglobals
+	PermEmployeesRead, PermEmployeesWrite,
+	PermPositionsRead, PermPositionsWrite,
+	PermSalaryRead, PermSalaryWrite,
+	PermDepartmentsRead, PermDepartmentsWrite,
+	PermAPIKeysAdmin, PermAttributesAdmin, PermRatesAdmin, PermMetricsAdmin,
+}
+
+func KnownPermission(permission string) bool {
+	for _, known := range allPermissions {
+		if known == permission {
+			return true
+		}
+	}
+	return false
+}
+
+type Policy struct {
+	roles map[string][]string
+}
+
+func NewPolicy(roles map[string][]string) *Policy {
+	return &Policy{roles: roles}
+}
+
+func (p *Policy) Permissions(roles []string) map[string]bool {
+	perms := map[string]bool{}
+	for _, role := range roles {
+		for _, perm := range p.roles[role] {
+			perms[perm] = true
+		}
+	}
+	return perms
+}
@@ -0,0 +1,189 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/VTerenya/employees/internal/ratelimit"
+)
+
+const (
+	EnvPath = "EMPLOYEES_CONFIG"
+
+	defaultAddr     = "localhost:8080"
+	defaultLogLevel = "info"
+)
+
+type Config struct {
+	Addr     string          `json:"addr"`
+	Log      Log             `json:"log"`
+	Auth     Auth            `json:"auth"`
+	RBAC     RBAC            `json:"rbac"`
+	Rate     Rate            `json:"rate_limit"`
+	CORS     CORS            `json:"cors"`
+	Headers  SecurityHeaders `json:"security_headers"`
+	TLS      TLS             `json:"tls"`
+	GRPC     GRPC            `json:"grpc"`
+	Currency Currency        `json:"currency"`
+}
+
+type Currency struct {
+	Base      string `json:"base"`
+	RatesFile string `json:"rates_file"`
+}
+
+type Log struct {
+	Level      string  `json:"level"`
+	SampleRate float64 `json:"sample_rate"`
+}
+
+type Auth struct {
+	Enabled          bool   `json:"enabled"`
+	HMACSecretFile   string `json:"hmac_secret_file"`
+	RSAPublicKeyFile string `json:"rsa_public_key_file"`
+	JWKSFile         string `json:"jwks_file"`
+	Issuer           string `json:"issuer"`
+	Audience         string `json:"audience"`
+	LeewaySeconds    int    `json:"leeway_seconds"`
+}
+
+type GRPC struct {
+	Enabled    bool   `json:"enabled"`
+	Addr       string `json:"addr"`
+	Reflection bool   `json:"reflection"`
+}
+
+type TLS struct {
+	Enabled               bool                `json:"enabled"`
+	CertFile              string              `json:"cert_file"`
+	KeyFile               string              `json:"key_file"`
+	ClientCAFile          string              `json:"client_ca_file"`
+	RequireClientCert     bool                `json:"require_client_cert"`
+	ReloadIntervalSeconds int                 `json:"reload_interval_seconds"`
+	ClientRoles           map[string][]string `json:"client_roles"`
+}
+
+type CORS struct {
+	AllowedOrigins   []string `json:"allowed_origins"`
+	AllowedMethods   []string `json:"allowed_methods"`
+	AllowedHeaders   []string `json:"allowed_headers"`
+	ExposedHeaders   []string `json:"exposed_headers"`
+	AllowCredentials bool     `json:"allow_credentials"`
+	MaxAgeSeconds    int      `json:"max_age_seconds"`
+}
+
+type SecurityHeaders struct {
+	ContentSecurityPolicy string `json:"content_security_policy"`
+	HSTSMaxAgeSeconds     int    `json:"hsts_max_age_seconds"`
+}
+
+type Rate struct {
+	Enabled bool `json:"enabled"`
+	ratelimit.Rules
+}
+
+type RBAC struct {
+	Roles map[string][]string `json:"roles"`
+}
+
+// UnmarshalJSON replaces the built-in roles when the config names any, so a
+// deployment can drop or narrow them instead of only adding new ones.
+func (r *RBAC) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Roles map[string][]string `json:"roles"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Roles != nil {
+		r.Roles = raw.Roles
+	}
+	return nil
+}
+
+func Default() *Config {
+	return &Config{
+		Addr: defaultAddr,
+		Log: Log{
+			Level:      defaultLogLevel,
+			SampleRate: 1,
+		},
+		Auth: Auth{
+			Enabled: true,
+		},
+		CORS: CORS{
+			AllowedMethods: []string{"GET", "POST", "PUT", "DELETE"},
+			AllowedHeaders: []string{
+				"Authorization", "Content-Type", "X-API-Key", "X-Request-ID", "X-Correlation-ID",
+			},
+			ExposedHeaders: []string{
+				"X-Request-ID", "X-Correlation-ID",
+				"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After",
+			},
+			MaxAgeSeconds: 600,
+		},
+		Headers: SecurityHeaders{
+			ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'",
+			HSTSMaxAgeSeconds:     31536000,
+		},
+		GRPC: GRPC{
+			Reflection: true,
+		},
+		TLS: TLS{
+			ReloadIntervalSeconds: 30,
+		},
+		Rate: Rate{
+			Rules: ratelimit.Rules{
+				Default: ratelimit.Limit{RequestsPerSecond: 10, Burst: 20},
+			},
+		},
+		Currency: Currency{
+			Base: "USD",
+		},
+		RBAC: RBAC{
+			Roles: map[string][]string{
+				"staff": {"employees:read", "positions:read", "departments:read"},
+				"hr_manager": {
+					"employees:read", "employees:write",
+					"positions:read", "positions:write", "salary:read",
+					"departments:read", "departments:write",
+				},
+				"compensation_admin": {
+					"employees:read", "employees:write",
+					"positions:read", "positions:write", "salary:read", "salary:write",
+					"departments:read", "rates:admin",
+				},
+				"admin": {
+					"employees:read", "employees:write",
+					"positions:read", "positions:write", "salary:read", "salary:write",
+					"departments:read", "departments:write",
+					"apikeys:admin", "attributes:admin", "rates:admin", "metrics:admin",
+				},
+			},
+		},
+	}
+}
+
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	if path == "" {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Rate.Enabled {
+		if err := cfg.Rate.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+func FromEnv() (*Config, error) {
+	return Load(os.Getenv(EnvPath))
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadRejectsZeroBurst(t *testing.T) {
+	for name, data := range map[string]string{
+		"default": `{"rate_limit":{"enabled":true,"default":{"requests_per_second":5,"burst":0}}}`,
+		"route":   `{"rate_limit":{"enabled":true,"routes":{"POST /employee":{"requests_per_second":1}}}}`,
+	} {
+		if _, err := Load(writeConfig(t, data)); err == nil {
+			t.Errorf("%s: zero burst accepted", name)
+		}
+	}
+	cfg, err := Load(writeConfig(t, `{"rate_limit":{"enabled":true}}`))
+	if err != nil {
+		t.Fatalf("default limits rejected: %v", err)
+	}
+	if !cfg.Rate.Default.Valid() {
+		t.Fatal("default limit is not valid")
+	}
+}
+
+func TestAuthEnabledUnlessDisabled(t *testing.T) {
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Auth.Enabled {
+		t.Fatal("auth disabled by default")
+	}
+	cfg, err = Load(writeConfig(t, `{"auth":{"hmac_secret_file":"secret"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Auth.Enabled {
+		t.Fatal("auth disabled by a config that does not mention enabled")
+	}
+	cfg, err = Load(writeConfig(t, `{"auth":{"enabled":false}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Auth.Enabled {
+		t.Fatal("explicit auth.enabled false ignored")
+	}
+}
+
+func TestConfiguredRolesReplaceDefaults(t *testing.T) {
+	cfg, err := Load(writeConfig(t, `{"rbac":{"roles":{"auditor":["employees:read"]}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.RBAC.Roles) != 1 || len(cfg.RBAC.Roles["auditor"]) != 1 {
+		t.Fatalf("roles = %v, want only auditor", cfg.RBAC.Roles)
+	}
+	cfg, err = Load(writeConfig(t, `{"rbac":{}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cfg.RBAC.Roles["admin"]; !ok {
+		t.Fatal("built-in roles dropped by a config without roles")
+	}
+}
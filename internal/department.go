@@ -0,0 +1,9 @@
+package internal
+
+import "github.com/google/uuid"
+
+type Department struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	CostCenter string    `json:"cost_center,omitempty"`
+}
@@ -1,10 +1,58 @@
 package internal
 
-import "github.com/google/uuid"
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EmploymentFullTime   = "full_time"
+	EmploymentPartTime   = "part_time"
+	EmploymentContractor = "contractor"
+)
+
+func ValidEmploymentType(t string) bool {
+	switch t {
+	case EmploymentFullTime, EmploymentPartTime, EmploymentContractor:
+		return true
+	}
+	return false
+}
 
 type Employee struct {
-	ID         uuid.UUID `json:"id"`
-	FirstName  string    `json:"first_name"`
-	LasName    string    `json:"las_name"`
-	PositionID uuid.UUID `json:"position_id"`
+	ID             uuid.UUID  `json:"id"`
+	FirstName      string     `json:"first_name"`
+	LastName       string     `json:"last_name"`
+	PositionID     uuid.UUID  `json:"position_id"`
+	ManagerID      *uuid.UUID `json:"manager_id,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	Email          string     `json:"email,omitempty"`
+	Phone          string     `json:"phone,omitempty"`
+	DateOfBirth    *Date      `json:"date_of_birth,omitempty"`
+	HireDate       *Date      `json:"hire_date,omitempty"`
+	EmploymentType string     `json:"employment_type,omitempty"`
+	WorkLocation   string     `json:"work_location,omitempty"`
+	EmployeeNumber string     `json:"employee_number,omitempty"`
+	Attributes     Attributes `json:"attributes,omitempty"`
+}
+
+func (e *Employee) UnmarshalJSON(data []byte) error {
+	type employee Employee
+	aux := struct {
+		*employee
+		LasName string `json:"las_name"`
+	}{employee: (*employee)(e)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if e.LastName == "" {
+		e.LastName = aux.LasName
+	}
+	return nil
+}
+
+type EmployeeWithPosition struct {
+	Employee
+	Position *Position `json:"position,omitempty"`
 }
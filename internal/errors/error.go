@@ -1,12 +1,33 @@
 package errors
 
 var (
-	badRequest          = newError("bad request")            // nolint: gochecknoglobals
-	notFound            = newError("not found")              // nolint: gochecknoglobals
-	positionIsExists    = newError("position is exists")     // nolint: gochecknoglobals
-	employeeIsExists    = newError("employee is exists")     // nolint: gochecknoglobals
-	internalServerError = newError("internal server error")  // nolint: gochecknoglobals
-	positionIsNotExists = newError("position is not exists") // nolint: gochecknoglobals
+	badRequest              = newError("bad request")                 // nolint: gochecknoglobals
+	notFound                = newError("not found")                   // nolint: gochecknoglobals
+	positionIsExists        = newError("position is exists")          // nolint: gochecknoglobals
+	employeeIsExists        = newError("employee is exists")          // nolint: gochecknoglobals
+	internalServerError     = newError("internal server error")       // nolint: gochecknoglobals
+	positionIsNotExists     = newError("position is not exists")      // nolint: gochecknoglobals
+	unauthorized            = newError("unauthorized")                // nolint: gochecknoglobals
+	forbidden               = newError("forbidden")                   // nolint: gochecknoglobals
+	apiKeyIsRevoked         = newError("api key is revoked")          // nolint: gochecknoglobals
+	departmentIsExists      = newError("department is exists")        // nolint: gochecknoglobals
+	departmentIsNotExists   = newError("department is not exists")    // nolint: gochecknoglobals
+	departmentIsNotEmpty    = newError("department is not empty")     // nolint: gochecknoglobals
+	managerIsNotExists      = newError("manager is not exists")       // nolint: gochecknoglobals
+	managerCycle            = newError("manager cycle")               // nolint: gochecknoglobals
+	employeeHasReports      = newError("employee has reports")        // nolint: gochecknoglobals
+	invalidTransition       = newError("invalid status transition")   // nolint: gochecknoglobals
+	emailIsExists           = newError("email is exists")             // nolint: gochecknoglobals
+	employeeNumberIsExists  = newError("employee number is exists")   // nolint: gochecknoglobals
+	attributeIsExists       = newError("attribute is exists")         // nolint: gochecknoglobals
+	invalidAttribute        = newError("invalid attribute")           // nolint: gochecknoglobals
+	payGradeIsExists        = newError("pay grade is exists")         // nolint: gochecknoglobals
+	payGradeIsNotExists     = newError("pay grade is not exists")     // nolint: gochecknoglobals
+	payGradeIsNotEmpty      = newError("pay grade is not empty")      // nolint: gochecknoglobals
+	salaryOutOfBand         = newError("salary is out of band")       // nolint: gochecknoglobals
+	exchangeRateIsNotExists = newError("exchange rate is not exists") // nolint: gochecknoglobals
+	employeeIsNotTerminated = newError("employee is not terminated")  // nolint: gochecknoglobals
+	positionNeedsTransfer   = newError("position needs transfer")     // nolint: gochecknoglobals
 )
 
 type Errors struct {
@@ -44,3 +65,87 @@ func StatusInternalServerError() error {
 func PositionIsNotExists() error {
 	return positionIsNotExists
 }
+
+func Unauthorized() error {
+	return unauthorized
+}
+
+func Forbidden() error {
+	return forbidden
+}
+
+func APIKeyIsRevoked() error {
+	return apiKeyIsRevoked
+}
+
+func DepartmentIsExists() error {
+	return departmentIsExists
+}
+
+func DepartmentIsNotExists() error {
+	return departmentIsNotExists
+}
+
+func DepartmentIsNotEmpty() error {
+	return departmentIsNotEmpty
+}
+
+func ManagerIsNotExists() error {
+	return managerIsNotExists
+}
+
+func ManagerCycle() error {
+	return managerCycle
+}
+
+func EmployeeHasReports() error {
+	return employeeHasReports
+}
+
+func InvalidTransition() error {
+	return invalidTransition
+}
+
+func EmailIsExists() error {
+	return emailIsExists
+}
+
+func EmployeeNumberIsExists() error {
+	return employeeNumberIsExists
+}
+
+func AttributeIsExists() error {
+	return attributeIsExists
+}
+
+func InvalidAttribute() error {
+	return invalidAttribute
+}
+
+func PayGradeIsExists() error {
+	return payGradeIsExists
+}
+
+func PayGradeIsNotExists() error {
+	return payGradeIsNotExists
+}
+
+func PayGradeIsNotEmpty() error {
+	return payGradeIsNotEmpty
+}
+
+func SalaryOutOfBand() error {
+	return salaryOutOfBand
+}
+
+func ExchangeRateIsNotExists() error {
+	return exchangeRateIsNotExists
+}
+
+func EmployeeIsNotTerminated() error {
+	return employeeIsNotTerminated
+}
+
+func PositionNeedsTransfer() error {
+	return positionNeedsTransfer
+}
@@ -0,0 +1,19 @@
+package internal
+
+type EmployeeFilter struct {
+	IDs         []string
+	FirstName   string
+	LastName    string
+	PositionIDs []string
+	Status      string
+	Attributes  map[string]string
+}
+
+type PositionFilter struct {
+	IDs  []string
+	Name string
+}
+
+type DepartmentFilter struct {
+	IDs []string
+}
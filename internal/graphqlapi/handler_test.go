@@ -0,0 +1,160 @@
+package graphqlapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/repository"
+	"github.com/VTerenya/employees/internal/requestctx"
+	"github.com/VTerenya/employees/internal/service"
+	"github.com/shopspring/decimal"
+)
+
+type countingRepo struct {
+	*repository.Repository
+	calls map[string]int
+}
+
+func (r *countingRepo) GetEmployees() map[string]internal.Employee {
+	r.calls["GetEmployees"]++
+	return r.Repository.GetEmployees()
+}
+
+func (r *countingRepo) GetPositions() map[string]internal.Position {
+	r.calls["GetPositions"]++
+	return r.Repository.GetPositions()
+}
+
+func (r *countingRepo) GetDepartments() map[string]internal.Department {
+	r.calls["GetDepartments"]++
+	return r.Repository.GetDepartments()
+}
+
+type result struct {
+	Data struct {
+		Employees []struct {
+			FirstName string
+			Position  struct {
+				Name       string
+				Department struct {
+					Name string
+				}
+			}
+		}
+	}
+	Errors []struct {
+		Message string
+	}
+}
+
+func identityContext(permissions ...string) context.Context {
+	ctx := requestctx.WithCorrelationID(context.Background(), "test")
+	if len(permissions) == 0 {
+		return auth.WithIdentity(ctx, auth.AnonymousIdentity())
+	}
+	granted := make(map[string]bool, len(permissions))
+	for _, p := range permissions {
+		granted[p] = true
+	}
+	return auth.WithIdentity(ctx, auth.Identity{Subject: "test", Permissions: granted})
+}
+
+func seed(t *testing.T) (*Handler, *countingRepo) {
+	t.Helper()
+	repo := &countingRepo{Repository: repository.NewRepo(repository.NewDataBase()), calls: map[string]int{}}
+	s := service.NewServ(repo, "USD")
+	ctx := identityContext()
+	for d := 0; d < 2; d++ {
+		department := internal.Department{Name: fmt.Sprintf("department %d", d)}
+		if err := s.CreateDepartment(ctx, &department); err != nil {
+			t.Fatal(err)
+		}
+		for p := 0; p < 2; p++ {
+			position := internal.Position{Name: fmt.Sprintf("position %d%d", d, p), Salary: decimal.NewFromInt(100),
+				DepartmentID: &department.ID}
+			if err := s.CreatePosition(ctx, &position); err != nil {
+				t.Fatal(err)
+			}
+			for e := 0; e < 3; e++ {
+				name := fmt.Sprintf("employee %d%d%d", d, p, e)
+				employee := internal.Employee{FirstName: name, LastName: name, PositionID: position.ID}
+				if err := s.CreateEmployee(ctx, &employee); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+	h, err := NewHandler(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo.calls = map[string]int{}
+	return h, repo
+}
+
+func query(t *testing.T, h *Handler, ctx context.Context, q string) result {
+	t.Helper()
+	body, err := json.Marshal(request{Query: q})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body)).WithContext(ctx)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var res result
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestNestedQueryBatchesLookups(t *testing.T) {
+	h, repo := seed(t)
+	res := query(t, h, identityContext(), `{ employees { firstName position { name department { name } } } }`)
+	if len(res.Errors) != 0 {
+		t.Fatalf("errors = %v", res.Errors)
+	}
+	if len(res.Data.Employees) != 12 {
+		t.Fatalf("got %d employees, want 12", len(res.Data.Employees))
+	}
+	for _, e := range res.Data.Employees {
+		var d, p int
+		if _, err := fmt.Sscanf(e.FirstName, "employee %1d%1d", &d, &p); err != nil {
+			t.Fatal(err)
+		}
+		if e.Position.Name != fmt.Sprintf("position %d%d", d, p) ||
+			e.Position.Department.Name != fmt.Sprintf("department %d", d) {
+			t.Errorf("%s: position %q in %q", e.FirstName, e.Position.Name, e.Position.Department.Name)
+		}
+	}
+	for _, method := range []string{"GetEmployees", "GetPositions", "GetDepartments"} {
+		if repo.calls[method] != 1 {
+			t.Errorf("%s called %d times, want 1", method, repo.calls[method])
+		}
+	}
+}
+
+func TestQueryRequiresEmployeesRead(t *testing.T) {
+	h, repo := seed(t)
+	ctx := identityContext(auth.PermPositionsRead, auth.PermDepartmentsRead)
+	res := query(t, h, ctx, `{ employees { firstName } }`)
+	if len(res.Errors) == 0 || res.Errors[0].Message != "forbidden" {
+		t.Fatalf("errors = %v", res.Errors)
+	}
+	if res.Data.Employees != nil {
+		t.Fatalf("employees = %v", res.Data.Employees)
+	}
+	if repo.calls["GetEmployees"] != 0 {
+		t.Fatalf("repository read %d times", repo.calls["GetEmployees"])
+	}
+}
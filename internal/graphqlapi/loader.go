@@ -0,0 +1,131 @@
+package graphqlapi
+
+import (
+	"context"
+
+	"github.com/VTerenya/employees/internal"
+)
+
+type loaders struct {
+	positions   *positionLoader
+	departments *departmentLoader
+	employees   *employeesByPositionLoader
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, service Service) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{
+		positions: &positionLoader{service: service, pending: map[string]bool{}, cache: map[string]internal.Position{}},
+		departments: &departmentLoader{
+			service: service, pending: map[string]bool{}, cache: map[string]internal.Department{},
+		},
+		employees: &employeesByPositionLoader{
+			service: service, pending: map[string]bool{}, cache: map[string][]internal.Employee{},
+		},
+	})
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	l, _ := ctx.Value(loadersKey{}).(*loaders)
+	return l
+}
+
+type thunk func() (interface{}, error)
+
+type positionLoader struct {
+	service Service
+	pending map[string]bool
+	cache   map[string]internal.Position
+}
+
+func (l *positionLoader) load(ctx context.Context, id string) thunk {
+	if _, ok := l.cache[id]; !ok {
+		l.pending[id] = true
+	}
+	return func() (interface{}, error) {
+		if len(l.pending) > 0 {
+			ids := make([]string, 0, len(l.pending))
+			for key := range l.pending {
+				ids = append(ids, key)
+			}
+			l.pending = map[string]bool{}
+			positions, err := l.service.FindPositions(ctx, internal.PositionFilter{IDs: ids})
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range positions {
+				l.cache[p.ID.String()] = p
+			}
+		}
+		p, ok := l.cache[id]
+		if !ok {
+			return nil, nil
+		}
+		return p, nil
+	}
+}
+
+type departmentLoader struct {
+	service Service
+	pending map[string]bool
+	cache   map[string]internal.Department
+}
+
+func (l *departmentLoader) load(ctx context.Context, id string) thunk {
+	if _, ok := l.cache[id]; !ok {
+		l.pending[id] = true
+	}
+	return func() (interface{}, error) {
+		if len(l.pending) > 0 {
+			ids := make([]string, 0, len(l.pending))
+			for key := range l.pending {
+				ids = append(ids, key)
+			}
+			l.pending = map[string]bool{}
+			departments, err := l.service.FindDepartments(ctx, internal.DepartmentFilter{IDs: ids})
+			if err != nil {
+				return nil, err
+			}
+			for _, d := range departments {
+				l.cache[d.ID.String()] = d
+			}
+		}
+		d, ok := l.cache[id]
+		if !ok {
+			return nil, nil
+		}
+		return d, nil
+	}
+}
+
+type employeesByPositionLoader struct {
+	service Service
+	pending map[string]bool
+	cache   map[string][]internal.Employee
+}
+
+func (l *employeesByPositionLoader) load(ctx context.Context, positionID string) thunk {
+	if _, ok := l.cache[positionID]; !ok {
+		l.pending[positionID] = true
+	}
+	return func() (interface{}, error) {
+		if len(l.pending) > 0 {
+			ids := make([]string, 0, len(l.pending))
+			for key := range l.pending {
+				ids = append(ids, key)
+				l.cache[key] = nil
+			}
+			l.pending = map[string]bool{}
+			employees, err := l.service.FindEmployees(ctx, internal.EmployeeFilter{PositionIDs: ids})
+			if err != nil {
+				return nil, err
+			}
+			for _, e := range employees {
+				key := e.PositionID.String()
+				l.cache[key] = append(l.cache[key], e)
+			}
+		}
+		return l.cache[positionID], nil
+	}
+}
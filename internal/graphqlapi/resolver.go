@@ -0,0 +1,173 @@
+package graphqlapi
+
+import (
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/graphql-go/graphql"
+	"github.com/shopspring/decimal"
+)
+
+type resolver struct {
+	service Service
+}
+
+func (r *resolver) employees(p graphql.ResolveParams) (interface{}, error) {
+	filter := internal.EmployeeFilter{
+		FirstName: stringArg(p, "firstName"),
+		LastName:  stringArg(p, "lastName"),
+		Status:    stringArg(p, "status"),
+	}
+	if filter.Status != "" && !internal.ValidStatus(filter.Status) {
+		return nil, errors.BadRequest()
+	}
+	if id := stringArg(p, "positionId"); id != "" {
+		filter.PositionIDs = []string{id}
+	}
+	employees, err := r.service.FindEmployees(p.Context, filter)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := page(p, len(employees))
+	if err != nil {
+		return nil, err
+	}
+	return employees[from:to], nil
+}
+
+func (r *resolver) employee(p graphql.ResolveParams) (interface{}, error) {
+	employees, err := r.service.FindEmployees(p.Context, internal.EmployeeFilter{IDs: []string{stringArg(p, "id")}})
+	if err != nil || len(employees) == 0 {
+		return nil, err
+	}
+	return employees[0], nil
+}
+
+func (r *resolver) positions(p graphql.ResolveParams) (interface{}, error) {
+	positions, err := r.service.FindPositions(p.Context, internal.PositionFilter{Name: stringArg(p, "name")})
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := page(p, len(positions))
+	if err != nil {
+		return nil, err
+	}
+	return positions[from:to], nil
+}
+
+func (r *resolver) position(p graphql.ResolveParams) (interface{}, error) {
+	return loadersFrom(p.Context).positions.load(p.Context, stringArg(p, "id"))()
+}
+
+func (r *resolver) createPosition(p graphql.ResolveParams) (interface{}, error) {
+	salary, err := parseSalary(stringArg(p, "salary"))
+	if err != nil {
+		return nil, err
+	}
+	position := internal.Position{Name: stringArg(p, "name"), Salary: salary, Currency: stringArg(p, "currency")}
+	if position.Name == "" || position.Salary.Equal(decimal.Zero) {
+		return nil, errors.BadRequest()
+	}
+	err = r.service.CreatePosition(p.Context, &position)
+	if err != nil {
+		return nil, err
+	}
+	return position, nil
+}
+
+func (r *resolver) updatePosition(p graphql.ResolveParams) (interface{}, error) {
+	position, err := r.service.GetPosition(p.Context, stringArg(p, "id"))
+	if err != nil {
+		return nil, err
+	}
+	if name, ok := p.Args["name"].(string); ok {
+		position.Name = name
+	}
+	if currency, ok := p.Args["currency"].(string); ok {
+		position.Currency = currency
+	}
+	if s, ok := p.Args["salary"].(string); ok {
+		position.Salary, err = parseSalary(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if position.Name == "" || position.Salary.Equal(decimal.Zero) {
+		return nil, errors.BadRequest()
+	}
+	err = r.service.UpdatePosition(p.Context, &position)
+	if err != nil {
+		return nil, err
+	}
+	return position, nil
+}
+
+func (r *resolver) deletePosition(p graphql.ResolveParams) (interface{}, error) {
+	err := r.service.DeletePosition(p.Context, stringArg(p, "id"))
+	if err != nil {
+		return nil, err
+	}
+	return true, nil
+}
+
+func (r *resolver) createEmployee(p graphql.ResolveParams) (interface{}, error) {
+	positionID, err := parseUUID(stringArg(p, "positionId"))
+	if err != nil {
+		return nil, err
+	}
+	employee := internal.Employee{
+		FirstName:  stringArg(p, "firstName"),
+		LastName:   stringArg(p, "lastName"),
+		PositionID: positionID,
+	}
+	err = applyProfileArgs(p, &employee)
+	if err != nil {
+		return nil, err
+	}
+	if employee.FirstName == "" || employee.LastName == "" {
+		return nil, errors.BadRequest()
+	}
+	err = r.service.CreateEmployee(p.Context, &employee)
+	if err != nil {
+		return nil, err
+	}
+	return employee, nil
+}
+
+func (r *resolver) updateEmployee(p graphql.ResolveParams) (interface{}, error) {
+	employee, err := r.service.GetEmployee(p.Context, stringArg(p, "id"))
+	if err != nil {
+		return nil, err
+	}
+	if firstName, ok := p.Args["firstName"].(string); ok {
+		employee.FirstName = firstName
+	}
+	if lastName, ok := p.Args["lastName"].(string); ok {
+		employee.LastName = lastName
+	}
+	if id, ok := p.Args["positionId"].(string); ok {
+		employee.PositionID, err = parseUUID(id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = applyProfileArgs(p, &employee)
+	if err != nil {
+		return nil, err
+	}
+	if employee.FirstName == "" || employee.LastName == "" {
+		return nil, errors.BadRequest()
+	}
+	err = r.service.UpdateEmployee(p.Context, &employee)
+	if err != nil {
+		return nil, err
+	}
+	return employee, nil
+}
+
+func (r *resolver) deleteEmployee(p graphql.ResolveParams) (interface{}, error) {
+	err := r.service.DeleteEmployee(p.Context, stringArg(p, "id"))
+	if err != nil {
+		return nil, err
+	}
+	return true, nil
+}
@@ -0,0 +1,339 @@
+package graphqlapi
+
+import (
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/shopspring/decimal"
+)
+
+const maxLimit = 100
+
+func pageArgs() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"limit":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: maxLimit},
+		"offset": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+	}
+}
+
+func withPageArgs(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	for name, arg := range pageArgs() {
+		args[name] = arg
+	}
+	return args
+}
+
+func page(p graphql.ResolveParams, n int) (int, int, error) {
+	limit, _ := p.Args["limit"].(int)
+	offset, _ := p.Args["offset"].(int)
+	if limit < 0 || limit > maxLimit || offset < 0 {
+		return 0, 0, errors.BadRequest()
+	}
+	if offset > n {
+		offset = n
+	}
+	end := offset + limit
+	if end > n {
+		end = n
+	}
+	return offset, end, nil
+}
+
+func stringArg(p graphql.ResolveParams, name string) string {
+	s, _ := p.Args[name].(string)
+	return s
+}
+
+func NewSchema(service Service) (graphql.Schema, error) {
+	departmentType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Department",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"costCenter": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return optional(p.Source.(internal.Department).CostCenter), nil
+				},
+			},
+		},
+	})
+	positionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Position",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"salary": &graphql.Field{
+				Type:        graphql.String,
+				Description: "Null for callers without the salary:read permission.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if !auth.Can(p.Context, auth.PermSalaryRead) {
+						return nil, nil
+					}
+					return p.Source.(internal.Position).Salary.String(), nil
+				},
+			},
+			"currency": &graphql.Field{
+				Type:        graphql.String,
+				Description: "ISO 4217 code of the salary. Null for callers without the salary:read permission.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if !auth.Can(p.Context, auth.PermSalaryRead) {
+						return nil, nil
+					}
+					return p.Source.(internal.Position).Currency, nil
+				},
+			},
+			"department": &graphql.Field{
+				Type: departmentType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id := p.Source.(internal.Position).DepartmentID
+					if id == nil {
+						return nil, nil
+					}
+					return (func() (interface{}, error))(loadersFrom(p.Context).departments.load(p.Context, id.String())), nil
+				},
+			},
+		},
+	})
+	employeeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Employee",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"firstName": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"lastName": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(internal.Employee).LastName, nil
+				},
+			},
+			"positionId": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(internal.Employee).PositionID.String(), nil
+				},
+			},
+			"email":          &graphql.Field{Type: graphql.String, Resolve: profileField},
+			"phone":          &graphql.Field{Type: graphql.String, Resolve: profileField},
+			"dateOfBirth":    &graphql.Field{Type: graphql.String, Resolve: profileField},
+			"hireDate":       &graphql.Field{Type: graphql.String, Resolve: profileField},
+			"employmentType": &graphql.Field{Type: graphql.String, Resolve: profileField},
+			"workLocation":   &graphql.Field{Type: graphql.String, Resolve: profileField},
+			"employeeNumber": &graphql.Field{Type: graphql.String, Resolve: profileField},
+			"status": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if status := p.Source.(internal.Employee).Status; status != "" {
+						return status, nil
+					}
+					return internal.StatusActive, nil
+				},
+			},
+			"position": &graphql.Field{
+				Type: positionType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					e := p.Source.(internal.Employee)
+					return (func() (interface{}, error))(loadersFrom(p.Context).positions.load(p.Context, e.PositionID.String())), nil
+				},
+			},
+		},
+	})
+	positionType.AddFieldConfig("employees", &graphql.Field{
+		Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(employeeType))),
+		Args: pageArgs(),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			id := p.Source.(internal.Position).ID.String()
+			load := loadersFrom(p.Context).employees.load(p.Context, id)
+			return func() (interface{}, error) {
+				v, err := load()
+				if err != nil {
+					return nil, err
+				}
+				employees, _ := v.([]internal.Employee)
+				from, to, err := page(p, len(employees))
+				if err != nil {
+					return nil, err
+				}
+				return employees[from:to], nil
+			}, nil
+		},
+	})
+	r := &resolver{service: service}
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"employees": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(employeeType))),
+				Args: withPageArgs(graphql.FieldConfigArgument{
+					"firstName":  &graphql.ArgumentConfig{Type: graphql.String},
+					"lastName":   &graphql.ArgumentConfig{Type: graphql.String},
+					"positionId": &graphql.ArgumentConfig{Type: graphql.ID},
+					"status":     &graphql.ArgumentConfig{Type: graphql.String},
+				}),
+				Resolve: r.employees,
+			},
+			"employee": &graphql.Field{
+				Type:    employeeType,
+				Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: r.employee,
+			},
+			"positions": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(positionType))),
+				Args: withPageArgs(graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{Type: graphql.String},
+				}),
+				Resolve: r.positions,
+			},
+			"position": &graphql.Field{
+				Type:    positionType,
+				Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: r.position,
+			},
+		},
+	})
+	idArg := graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}}
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createPosition": &graphql.Field{
+				Type: graphql.NewNonNull(positionType),
+				Args: graphql.FieldConfigArgument{
+					"name":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"salary":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"currency": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: r.createPosition,
+			},
+			"updatePosition": &graphql.Field{
+				Type: graphql.NewNonNull(positionType),
+				Args: graphql.FieldConfigArgument{
+					"id":       &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"name":     &graphql.ArgumentConfig{Type: graphql.String},
+					"salary":   &graphql.ArgumentConfig{Type: graphql.String},
+					"currency": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: r.updatePosition,
+			},
+			"deletePosition": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.Boolean),
+				Args:    idArg,
+				Resolve: r.deletePosition,
+			},
+			"createEmployee": &graphql.Field{
+				Type: graphql.NewNonNull(employeeType),
+				Args: withProfileArgs(graphql.FieldConfigArgument{
+					"firstName":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"lastName":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"positionId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				}),
+				Resolve: r.createEmployee,
+			},
+			"updateEmployee": &graphql.Field{
+				Type: graphql.NewNonNull(employeeType),
+				Args: withProfileArgs(graphql.FieldConfigArgument{
+					"id":         &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"firstName":  &graphql.ArgumentConfig{Type: graphql.String},
+					"lastName":   &graphql.ArgumentConfig{Type: graphql.String},
+					"positionId": &graphql.ArgumentConfig{Type: graphql.ID},
+				}),
+				Resolve: r.updateEmployee,
+			},
+			"deleteEmployee": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.Boolean),
+				Args:    idArg,
+				Resolve: r.deleteEmployee,
+			},
+		},
+	})
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+func parseSalary(s string) (decimal.Decimal, error) {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero, errors.BadRequest()
+	}
+	return d, nil
+}
+
+func parseUUID(s string) (uuid.UUID, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, errors.BadRequest()
+	}
+	return id, nil
+}
+
+var profileArgs = []string{ // nolint: gochecknoglobals
+	"email", "phone", "dateOfBirth", "hireDate", "employmentType", "workLocation", "employeeNumber",
+}
+
+func withProfileArgs(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	for _, name := range profileArgs {
+		args[name] = &graphql.ArgumentConfig{Type: graphql.String}
+	}
+	return args
+}
+
+func dateString(d *internal.Date) interface{} {
+	if d == nil {
+		return nil
+	}
+	return d.String()
+}
+
+func optional(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func profileField(p graphql.ResolveParams) (interface{}, error) {
+	e := p.Source.(internal.Employee)
+	switch p.Info.FieldName {
+	case "email":
+		return optional(e.Email), nil
+	case "phone":
+		return optional(e.Phone), nil
+	case "dateOfBirth":
+		return dateString(e.DateOfBirth), nil
+	case "hireDate":
+		return dateString(e.HireDate), nil
+	case "employmentType":
+		return optional(e.EmploymentType), nil
+	case "workLocation":
+		return optional(e.WorkLocation), nil
+	case "employeeNumber":
+		return optional(e.EmployeeNumber), nil
+	}
+	return nil, nil
+}
+
+func applyProfileArgs(p graphql.ResolveParams, e *internal.Employee) error {
+	for name, target := range map[string]*string{
+		"email": &e.Email, "phone": &e.Phone, "employmentType": &e.EmploymentType,
+		"workLocation": &e.WorkLocation, "employeeNumber": &e.EmployeeNumber,
+	} {
+		if v, ok := p.Args[name].(string); ok {
+			*target = v
+		}
+	}
+	for name, target := range map[string]**internal.Date{"dateOfBirth": &e.DateOfBirth, "hireDate": &e.HireDate} {
+		v, ok := p.Args[name].(string)
+		if !ok {
+			continue
+		}
+		if v == "" {
+			*target = nil
+			continue
+		}
+		d, err := internal.ParseDate(v)
+		if err != nil {
+			return errors.BadRequest()
+		}
+		*target = &d
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package graphqlapi
+
+import (
+	"context"
+
+	"github.com/VTerenya/employees/internal"
+)
+
+type Service interface {
+	FindEmployees(ctx context.Context, filter internal.EmployeeFilter) ([]internal.Employee, error)
+	FindPositions(ctx context.Context, filter internal.PositionFilter) ([]internal.Position, error)
+	FindDepartments(ctx context.Context, filter internal.DepartmentFilter) ([]internal.Department, error)
+	GetEmployee(ctx context.Context, id string) (internal.Employee, error)
+	GetPosition(ctx context.Context, id string) (internal.Position, error)
+	CreatePosition(ctx context.Context, p *internal.Position) error
+	CreateEmployee(ctx context.Context, e *internal.Employee) error
+	UpdatePosition(ctx context.Context, p *internal.Position) error
+	UpdateEmployee(ctx context.Context, e *internal.Employee) error
+	DeletePosition(ctx context.Context, id string) error
+	DeleteEmployee(ctx context.Context, id string) error
+}
@@ -0,0 +1,188 @@
+package grpcserver
+
+import (
+	"context"
+
+	employeesv1 "github.com/VTerenya/employees/api/employees/v1"
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func parseID(id string) (uuid.UUID, error) {
+	if id == "" {
+		return uuid.Nil, nil
+	}
+	u, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, errors.BadRequest()
+	}
+	return u, nil
+}
+
+func parseDate(s string) (*internal.Date, error) {
+	if s == "" {
+		return nil, nil
+	}
+	d, err := internal.ParseDate(s)
+	if err != nil {
+		return nil, errors.BadRequest()
+	}
+	return &d, nil
+}
+
+func attributesToProto(attrs internal.Attributes) *structpb.Struct {
+	if len(attrs) == 0 {
+		return nil
+	}
+	answer, err := structpb.NewStruct(attrs)
+	if err != nil {
+		return nil
+	}
+	return answer
+}
+
+func attributesFromProto(s *structpb.Struct) internal.Attributes {
+	if len(s.GetFields()) == 0 {
+		return nil
+	}
+	return s.AsMap()
+}
+
+// positionToProto leaves out compensation for callers without salary:read,
+// like the REST and GraphQL APIs.
+func positionToProto(ctx context.Context, p internal.Position) *employeesv1.Position {
+	salary := auth.Can(ctx, auth.PermSalaryRead)
+	if !salary {
+		p = p.Redacted()
+	}
+	answer := &employeesv1.Position{
+		Id:                   p.ID.String(),
+		Name:                 p.Name,
+		Salary:               p.Salary.String(),
+		Currency:             p.Currency,
+		Attributes:           attributesToProto(p.Attributes),
+		SalaryOverrideReason: p.SalaryOverrideReason,
+	}
+	if p.DepartmentID != nil {
+		answer.DepartmentId = p.DepartmentID.String()
+	}
+	if p.PayGradeID != nil {
+		answer.PayGradeId = p.PayGradeID.String()
+	}
+	if !salary {
+		answer.Salary = ""
+	}
+	return answer
+}
+
+func positionFromProto(p *employeesv1.Position) (internal.Position, error) {
+	if p == nil {
+		return internal.Position{}, errors.BadRequest()
+	}
+	id, err := parseID(p.GetId())
+	if err != nil {
+		return internal.Position{}, err
+	}
+	salary := decimal.Zero
+	if p.GetSalary() != "" {
+		salary, err = decimal.NewFromString(p.GetSalary())
+		if err != nil {
+			return internal.Position{}, errors.BadRequest()
+		}
+	}
+	position := internal.Position{
+		ID:                   id,
+		Name:                 p.GetName(),
+		Salary:               salary,
+		Currency:             p.GetCurrency(),
+		SalaryOverrideReason: p.GetSalaryOverrideReason(),
+		Attributes:           attributesFromProto(p.GetAttributes()),
+	}
+	if p.GetDepartmentId() != "" {
+		departmentID, err := parseID(p.GetDepartmentId())
+		if err != nil {
+			return internal.Position{}, err
+		}
+		position.DepartmentID = &departmentID
+	}
+	if p.GetPayGradeId() != "" {
+		payGradeID, err := parseID(p.GetPayGradeId())
+		if err != nil {
+			return internal.Position{}, err
+		}
+		position.PayGradeID = &payGradeID
+	}
+	return position, nil
+}
+
+func employeeToProto(e internal.Employee) *employeesv1.Employee {
+	answer := &employeesv1.Employee{
+		Id:             e.ID.String(),
+		FirstName:      e.FirstName,
+		LastName:       e.LastName,
+		PositionId:     e.PositionID.String(),
+		Status:         e.Status,
+		Email:          e.Email,
+		Phone:          e.Phone,
+		EmploymentType: e.EmploymentType,
+		WorkLocation:   e.WorkLocation,
+		EmployeeNumber: e.EmployeeNumber,
+		Attributes:     attributesToProto(e.Attributes),
+	}
+	if e.ManagerID != nil {
+		answer.ManagerId = e.ManagerID.String()
+	}
+	if e.DateOfBirth != nil {
+		answer.DateOfBirth = e.DateOfBirth.String()
+	}
+	if e.HireDate != nil {
+		answer.HireDate = e.HireDate.String()
+	}
+	return answer
+}
+
+func employeeFromProto(e *employeesv1.Employee) (internal.Employee, error) {
+	if e == nil {
+		return internal.Employee{}, errors.BadRequest()
+	}
+	id, err := parseID(e.GetId())
+	if err != nil {
+		return internal.Employee{}, err
+	}
+	positionID, err := parseID(e.GetPositionId())
+	if err != nil {
+		return internal.Employee{}, err
+	}
+	employee := internal.Employee{
+		ID:             id,
+		FirstName:      e.GetFirstName(),
+		LastName:       e.GetLastName(),
+		PositionID:     positionID,
+		Email:          e.GetEmail(),
+		Phone:          e.GetPhone(),
+		EmploymentType: e.GetEmploymentType(),
+		WorkLocation:   e.GetWorkLocation(),
+		EmployeeNumber: e.GetEmployeeNumber(),
+		Attributes:     attributesFromProto(e.GetAttributes()),
+	}
+	employee.DateOfBirth, err = parseDate(e.GetDateOfBirth())
+	if err != nil {
+		return internal.Employee{}, err
+	}
+	employee.HireDate, err = parseDate(e.GetHireDate())
+	if err != nil {
+		return internal.Employee{}, err
+	}
+	if e.GetManagerId() != "" {
+		managerID, err := parseID(e.GetManagerId())
+		if err != nil {
+			return internal.Employee{}, err
+		}
+		employee.ManagerID = &managerID
+	}
+	return employee, nil
+}
@@ -0,0 +1,38 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestPositionToProtoRedactsCompensation(t *testing.T) {
+	grade := uuid.New()
+	p := internal.Position{
+		ID:                   uuid.New(),
+		Name:                 "dev",
+		Salary:               decimal.NewFromInt(1000),
+		Currency:             "EUR",
+		PayGradeID:           &grade,
+		SalaryOverrideReason: "retention",
+	}
+	reader := auth.WithIdentity(context.Background(), auth.Identity{
+		Permissions: map[string]bool{auth.PermPositionsRead: true},
+	})
+	got := positionToProto(reader, p)
+	if got.GetSalary() != "" || got.GetCurrency() != "" || got.GetPayGradeId() != "" ||
+		got.GetSalaryOverrideReason() != "" {
+		t.Fatalf("compensation leaked without salary:read: %v", got)
+	}
+	if got.GetName() != "dev" {
+		t.Fatalf("name = %q", got.GetName())
+	}
+	full := positionToProto(auth.WithIdentity(context.Background(), auth.AnonymousIdentity()), p)
+	if full.GetSalary() != "1000" || full.GetCurrency() != "EUR" || full.GetPayGradeId() != grade.String() {
+		t.Fatalf("compensation missing with salary:read: %v", full)
+	}
+}
@@ -0,0 +1,44 @@
+package grpcserver
+
+import (
+	employeesv1 "github.com/VTerenya/employees/api/employees/v1"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/handler"
+	"github.com/VTerenya/employees/internal/middleware"
+	"github.com/VTerenya/employees/internal/ratelimit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+type Options struct {
+	Verifier    *auth.JWTVerifier
+	Policy      *auth.Policy
+	APIKeys     middleware.APIKeyAuthenticator
+	ClientRoles map[string][]string
+	Reflection  bool
+	Limiter     ratelimit.Limiter
+	RateRules   ratelimit.Rules
+}
+
+func New(service handler.Service, opts Options, serverOpts ...grpc.ServerOption) *grpc.Server {
+	a := &authenticator{
+		verifier:    opts.Verifier,
+		policy:      opts.Policy,
+		apiKeys:     opts.APIKeys,
+		clientRoles: opts.ClientRoles,
+	}
+	interceptors := []grpc.UnaryServerInterceptor{correlationInterceptor, logInterceptor, recoveryInterceptor}
+	if opts.Limiter != nil {
+		l := &rateLimiter{limiter: opts.Limiter, rules: opts.RateRules}
+		interceptors = append(interceptors, l.interceptor)
+	}
+	interceptors = append(interceptors, a.interceptor)
+	serverOpts = append(serverOpts, grpc.ChainUnaryInterceptor(interceptors...))
+	s := grpc.NewServer(serverOpts...)
+	employeesv1.RegisterPositionServiceServer(s, &positionServer{service: service})
+	employeesv1.RegisterEmployeeServiceServer(s, &employeeServer{service: service})
+	if opts.Reflection {
+		reflection.Register(s)
+	}
+	return s
+}
@@ -0,0 +1,210 @@
+package grpcserver
+
+import (
+	"context"
+	"expvar"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/middleware"
+	"github.com/VTerenya/employees/internal/ratelimit"
+	"github.com/VTerenya/employees/internal/requestctx"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var panicsTotal = expvar.NewInt("grpc_panics_total") // nolint: gochecknoglobals
+
+const (
+	mdRequestID     = "x-request-id"
+	mdCorrelationID = "x-correlation-id"
+	mdAPIKey        = "x-api-key"
+	mdAuthorization = "authorization"
+)
+
+func firstMD(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func correlationInterceptor(
+	ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, next grpc.UnaryHandler,
+) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := ""
+	for _, key := range []string{mdRequestID, mdCorrelationID} {
+		if v := firstMD(md, key); middleware.ValidCorrelationID(v) {
+			id = v
+			break
+		}
+	}
+	if id == "" {
+		id = uuid.New().String()
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(mdRequestID, id)); err != nil {
+		logrus.WithError(err).Debug("set grpc header")
+	}
+	return next(requestctx.WithCorrelationID(ctx, id), req)
+}
+
+func logInterceptor(
+	ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, next grpc.UnaryHandler,
+) (interface{}, error) {
+	start := time.Now()
+	fields := logrus.Fields{
+		requestctx.CorrelationIDKey: requestctx.CorrelationID(ctx),
+		"grpc_method":               info.FullMethod,
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		fields["remote_addr"] = p.Addr.String()
+	}
+	ctx = requestctx.WithLogger(ctx, logrus.NewEntry(logrus.StandardLogger()).WithFields(fields))
+	resp, err := next(ctx, req)
+	requestctx.Logger(ctx).WithFields(logrus.Fields{
+		"type":        "access log",
+		"code":        status.Code(err).String(),
+		"duration_ms": float64(time.Since(start).Microseconds()) / 1000,
+	}).Info()
+	return resp, err
+}
+
+func recoveryInterceptor(
+	ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, next grpc.UnaryHandler,
+) (resp interface{}, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			panicsTotal.Add(1)
+			middleware.LogPanic(ctx, rec)
+			resp, err = nil, status.Error(codes.Internal, "internal server error")
+		}
+	}()
+	return next(ctx, req)
+}
+
+type rateLimiter struct {
+	limiter ratelimit.Limiter
+	rules   ratelimit.Rules
+}
+
+// rateLimitClient mirrors the HTTP middleware: gRPC calls are HTTP/2 POSTs
+// to the full method name, so route rules such as
+// "POST /employees.v1.EmployeeService/CreateEmployee" apply to them as well.
+func rateLimitClient(ctx context.Context) string {
+	subject, addr := "", ""
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.VerifiedChains) > 0 {
+			subject = auth.CertificateSubject(tlsInfo.State.VerifiedChains[0][0])
+		}
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	credential := firstMD(md, mdAPIKey)
+	if credential == "" {
+		credential = firstMD(md, mdAuthorization)
+	}
+	return ratelimit.ClientKey(subject, credential, addr)
+}
+
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+func (l *rateLimiter) interceptor(
+	ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, next grpc.UnaryHandler,
+) (interface{}, error) {
+	limit, bucket := l.rules.For("POST", info.FullMethod)
+	if !limit.Valid() {
+		return next(ctx, req)
+	}
+	res, err := l.limiter.Allow(ctx, rateLimitClient(ctx)+"|"+bucket, limit)
+	if err != nil {
+		requestctx.Logger(ctx).WithError(err).Error("rate limiter unavailable")
+		return next(ctx, req)
+	}
+	md := metadata.Pairs(
+		"ratelimit-limit", strconv.Itoa(res.Limit),
+		"ratelimit-remaining", strconv.Itoa(res.Remaining),
+		"ratelimit-reset", seconds(res.Reset),
+	)
+	if !res.Allowed {
+		md.Set("retry-after", seconds(res.RetryAfter))
+	}
+	if err := grpc.SetHeader(ctx, md); err != nil {
+		requestctx.Logger(ctx).WithError(err).Debug("set grpc header")
+	}
+	if !res.Allowed {
+		return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	return next(ctx, req)
+}
+
+type authenticator struct {
+	verifier    *auth.JWTVerifier
+	policy      *auth.Policy
+	apiKeys     middleware.APIKeyAuthenticator
+	clientRoles map[string][]string
+}
+
+func (a *authenticator) identity(ctx context.Context) (auth.Identity, bool, error) {
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.VerifiedChains) > 0 {
+			id, ok := auth.CertificateIdentity(tlsInfo.State.VerifiedChains[0][0], a.clientRoles, a.policy)
+			if ok {
+				return id, true, nil
+			}
+		}
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	authorization := firstMD(md, mdAuthorization)
+	key := firstMD(md, mdAPIKey)
+	if key == "" && strings.HasPrefix(strings.ToLower(authorization), "apikey ") {
+		key = strings.TrimSpace(authorization[len("apikey "):])
+	}
+	if key != "" {
+		k, err := a.apiKeys.AuthenticateAPIKey(ctx, key)
+		if err != nil {
+			return auth.Identity{}, false, status.Error(codes.Unauthenticated, "invalid api key")
+		}
+		return auth.APIKeyIdentity(k), true, nil
+	}
+	if a.verifier == nil {
+		return auth.Identity{}, false, nil
+	}
+	if !strings.HasPrefix(strings.ToLower(authorization), "bearer ") {
+		return auth.Identity{}, false, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	claims, err := a.verifier.Verify(strings.TrimSpace(authorization[len("bearer "):]))
+	if err != nil {
+		requestctx.Logger(ctx).WithError(err).Warn("rejected bearer token")
+		return auth.Identity{}, false, status.Error(codes.Unauthenticated, "invalid bearer token")
+	}
+	return auth.JWTIdentity(claims, a.policy), true, nil
+}
+
+func (a *authenticator) interceptor(
+	ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, next grpc.UnaryHandler,
+) (interface{}, error) {
+	id, ok, err := a.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok && a.verifier == nil {
+		id, ok = auth.AnonymousIdentity(), true
+	}
+	if ok {
+		requestctx.AddLogFields(ctx, logrus.Fields{"user": id.Subject})
+		ctx = auth.WithIdentity(ctx, id)
+	}
+	return next(ctx, req)
+}
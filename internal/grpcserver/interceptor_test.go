@@ -0,0 +1,85 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/VTerenya/employees/internal/ratelimit"
+	"github.com/VTerenya/employees/internal/requestctx"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimitInterceptor(t *testing.T) {
+	l := &rateLimiter{
+		limiter: ratelimit.NewMemoryLimiter(),
+		rules: ratelimit.Rules{
+			Default: ratelimit.Limit{RequestsPerSecond: 1, Burst: 5},
+			Routes: map[string]ratelimit.Limit{
+				"POST /employees.v1.EmployeeService/CreateEmployee": {RequestsPerSecond: 1, Burst: 1},
+			},
+		},
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/employees.v1.EmployeeService/CreateEmployee"}
+	call := func(apiKey string) codes.Code {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 4000},
+		})
+		if apiKey != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(mdAPIKey, apiKey))
+		}
+		_, err := l.interceptor(ctx, nil, info, func(context.Context, interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return status.Code(err)
+	}
+	if code := call(""); code != codes.OK {
+		t.Fatalf("first call: %v", code)
+	}
+	if code := call(""); code != codes.ResourceExhausted {
+		t.Fatalf("second call from the same address: %v", code)
+	}
+	if code := call("key-one"); code != codes.OK {
+		t.Fatalf("call with an api key behind the same address: %v", code)
+	}
+	if code := call("key-one"); code != codes.ResourceExhausted {
+		t.Fatalf("second call with the same api key: %v", code)
+	}
+}
+
+func TestCorrelationInterceptor(t *testing.T) {
+	for _, tt := range []struct {
+		md   metadata.MD
+		want string
+	}{
+		{metadata.Pairs(mdRequestID, "req-1"), "req-1"},
+		{metadata.Pairs(mdCorrelationID, "corr-2"), "corr-2"},
+		{metadata.Pairs(mdRequestID, "bad id!", mdCorrelationID, "corr-3"), "corr-3"},
+		{metadata.MD{}, ""},
+	} {
+		var seen string
+		ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+		_, err := correlationInterceptor(ctx, nil, &grpc.UnaryServerInfo{},
+			func(ctx context.Context, _ interface{}) (interface{}, error) {
+				seen = requestctx.CorrelationID(ctx)
+				return nil, nil
+			})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.want == "" {
+			if _, err := uuid.Parse(seen); err != nil {
+				t.Errorf("%v: generated id %q is not a uuid", tt.md, seen)
+			}
+			continue
+		}
+		if seen != tt.want {
+			t.Errorf("%v: handler saw %q, want %q", tt.md, seen, tt.want)
+		}
+	}
+}
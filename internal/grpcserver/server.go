@@ -0,0 +1,175 @@
+package grpcserver
+
+import (
+	"context"
+
+	employeesv1 "github.com/VTerenya/employees/api/employees/v1"
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/VTerenya/employees/internal/handler"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type positionServer struct {
+	employeesv1.UnimplementedPositionServiceServer
+	service handler.Service
+}
+
+type employeeServer struct {
+	employeesv1.UnimplementedEmployeeServiceServer
+	service handler.Service
+}
+
+func validID(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return toStatus(errors.BadRequest())
+	}
+	return nil
+}
+
+func (s *positionServer) ListPositions(
+	ctx context.Context, req *employeesv1.ListPositionsRequest,
+) (*employeesv1.ListPositionsResponse, error) {
+	positions, err := s.service.GetPositions(ctx, int(req.GetLimit()), int(req.GetOffset()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if req.GetCurrency() != "" {
+		positions, err = s.service.ConvertPositions(ctx, positions, req.GetCurrency())
+		if err != nil {
+			return nil, toStatus(err)
+		}
+	}
+	resp := &employeesv1.ListPositionsResponse{Positions: make([]*employeesv1.Position, 0, len(positions))}
+	for _, p := range positions {
+		resp.Positions = append(resp.Positions, positionToProto(ctx, p))
+	}
+	return resp, nil
+}
+
+func (s *positionServer) GetPosition(ctx context.Context, req *employeesv1.GetPositionRequest) (*employeesv1.Position, error) {
+	if err := validID(req.GetId()); err != nil {
+		return nil, err
+	}
+	p, err := s.service.GetPosition(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if req.GetCurrency() != "" {
+		converted, err := s.service.ConvertPositions(ctx, []internal.Position{p}, req.GetCurrency())
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		p = converted[0]
+	}
+	return positionToProto(ctx, p), nil
+}
+
+func (s *positionServer) CreatePosition(
+	ctx context.Context, req *employeesv1.CreatePositionRequest,
+) (*employeesv1.Position, error) {
+	p, err := positionFromProto(req.GetPosition())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if p.Salary.Equal(decimal.Zero) || p.Name == "" {
+		return nil, toStatus(errors.BadRequest())
+	}
+	if err := s.service.CreatePosition(ctx, &p); err != nil {
+		return nil, toStatus(err)
+	}
+	return positionToProto(ctx, p), nil
+}
+
+func (s *positionServer) UpdatePosition(
+	ctx context.Context, req *employeesv1.UpdatePositionRequest,
+) (*employeesv1.Position, error) {
+	p, err := positionFromProto(req.GetPosition())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.service.UpdatePosition(ctx, &p); err != nil {
+		return nil, toStatus(err)
+	}
+	return positionToProto(ctx, p), nil
+}
+
+func (s *positionServer) DeletePosition(
+	ctx context.Context, req *employeesv1.DeletePositionRequest,
+) (*employeesv1.DeletePositionResponse, error) {
+	if err := validID(req.GetId()); err != nil {
+		return nil, err
+	}
+	if err := s.service.DeletePosition(ctx, req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &employeesv1.DeletePositionResponse{}, nil
+}
+
+func (s *employeeServer) ListEmployees(
+	ctx context.Context, req *employeesv1.ListEmployeesRequest,
+) (*employeesv1.ListEmployeesResponse, error) {
+	filter := internal.EmployeeFilter{Status: req.GetStatus(), Attributes: req.GetAttributes()}
+	employees, err := s.service.GetEmployees(ctx, int(req.GetLimit()), int(req.GetOffset()), filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &employeesv1.ListEmployeesResponse{Employees: make([]*employeesv1.Employee, 0, len(employees))}
+	for _, e := range employees {
+		resp.Employees = append(resp.Employees, employeeToProto(e))
+	}
+	return resp, nil
+}
+
+func (s *employeeServer) GetEmployee(ctx context.Context, req *employeesv1.GetEmployeeRequest) (*employeesv1.Employee, error) {
+	if err := validID(req.GetId()); err != nil {
+		return nil, err
+	}
+	e, err := s.service.GetEmployee(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return employeeToProto(e), nil
+}
+
+func (s *employeeServer) CreateEmployee(
+	ctx context.Context, req *employeesv1.CreateEmployeeRequest,
+) (*employeesv1.Employee, error) {
+	e, err := employeeFromProto(req.GetEmployee())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if e.LastName == "" || e.FirstName == "" || e.PositionID == uuid.Nil {
+		return nil, toStatus(errors.BadRequest())
+	}
+	if err := s.service.CreateEmployee(ctx, &e); err != nil {
+		return nil, toStatus(err)
+	}
+	return employeeToProto(e), nil
+}
+
+func (s *employeeServer) UpdateEmployee(
+	ctx context.Context, req *employeesv1.UpdateEmployeeRequest,
+) (*employeesv1.Employee, error) {
+	e, err := employeeFromProto(req.GetEmployee())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.service.UpdateEmployee(ctx, &e); err != nil {
+		return nil, toStatus(err)
+	}
+	return employeeToProto(e), nil
+}
+
+func (s *employeeServer) DeleteEmployee(
+	ctx context.Context, req *employeesv1.DeleteEmployeeRequest,
+) (*employeesv1.DeleteEmployeeResponse, error) {
+	if err := validID(req.GetId()); err != nil {
+		return nil, err
+	}
+	if err := s.service.DeleteEmployee(ctx, req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &employeesv1.DeleteEmployeeResponse{}, nil
+}
@@ -0,0 +1,40 @@
+package grpcserver
+
+import (
+	errs "errors"
+
+	"github.com/VTerenya/employees/internal/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	code := codes.Internal
+	switch {
+	case errs.Is(err, errors.BadRequest()), errs.Is(err, errors.InvalidAttribute()):
+		code = codes.InvalidArgument
+	case errs.Is(err, errors.NotFound()):
+		code = codes.NotFound
+	case errs.Is(err, errors.PositionIsExists()), errs.Is(err, errors.EmployeeIsExists()),
+		errs.Is(err, errors.DepartmentIsExists()), errs.Is(err, errors.EmailIsExists()),
+		errs.Is(err, errors.EmployeeNumberIsExists()), errs.Is(err, errors.AttributeIsExists()),
+		errs.Is(err, errors.PayGradeIsExists()):
+		code = codes.AlreadyExists
+	case errs.Is(err, errors.PositionIsNotExists()), errs.Is(err, errors.APIKeyIsRevoked()),
+		errs.Is(err, errors.DepartmentIsNotExists()), errs.Is(err, errors.DepartmentIsNotEmpty()),
+		errs.Is(err, errors.ManagerIsNotExists()), errs.Is(err, errors.ManagerCycle()),
+		errs.Is(err, errors.EmployeeHasReports()), errs.Is(err, errors.InvalidTransition()),
+		errs.Is(err, errors.PayGradeIsNotExists()), errs.Is(err, errors.PayGradeIsNotEmpty()),
+		errs.Is(err, errors.SalaryOutOfBand()), errs.Is(err, errors.ExchangeRateIsNotExists()),
+		errs.Is(err, errors.EmployeeIsNotTerminated()), errs.Is(err, errors.PositionNeedsTransfer()):
+		code = codes.FailedPrecondition
+	case errs.Is(err, errors.Unauthorized()):
+		code = codes.Unauthenticated
+	case errs.Is(err, errors.Forbidden()):
+		code = codes.PermissionDenied
+	}
+	return status.Error(code, err.Error())
+}
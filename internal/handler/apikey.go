@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"encoding/json"
+	errs "errors"
+	"net/http"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/gorilla/mux"
+)
+
+func (h *Hand) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.service.GetAPIKeys(r.Context())
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	jsonBytes, err := json.Marshal(keys)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Hand) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var k internal.APIKey
+	if err := json.NewDecoder(r.Body).Decode(&k); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	secret, err := h.service.CreateAPIKey(r.Context(), &k)
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	jsonBytes, err := json.Marshal(internal.APIKeyWithSecret{APIKey: k, Secret: secret})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Hand) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if len(vars) == 0 {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	k, secret, err := h.service.RotateAPIKey(r.Context(), vars["id"])
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.APIKeyIsRevoked()) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	jsonBytes, err := json.Marshal(internal.APIKeyWithSecret{APIKey: k, Secret: secret})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Hand) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if len(vars) == 0 {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	err := h.service.RevokeAPIKey(r.Context(), vars["id"])
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"encoding/json"
+	errs "errors"
+	"net/http"
+	"strings"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/gorilla/mux"
+)
+
+const attributeQueryPrefix = "attr."
+
+func employeeFilter(r *http.Request) internal.EmployeeFilter {
+	filter := internal.EmployeeFilter{Status: r.URL.Query().Get("status")}
+	for key, values := range r.URL.Query() {
+		if !strings.HasPrefix(key, attributeQueryPrefix) || len(values) == 0 {
+			continue
+		}
+		if filter.Attributes == nil {
+			filter.Attributes = make(map[string]string)
+		}
+		filter.Attributes[strings.TrimPrefix(key, attributeQueryPrefix)] = values[0]
+	}
+	return filter
+}
+
+func (h *Hand) GetAttributeDefinitions(w http.ResponseWriter, r *http.Request) {
+	definitions, err := h.service.GetAttributeDefinitions(r.Context(), r.URL.Query().Get("entity"))
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.BadRequest()) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	jsonBytes, err := json.Marshal(definitions)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Hand) CreateAttributeDefinition(w http.ResponseWriter, r *http.Request) {
+	var d internal.AttributeDefinition
+	if err := json.NewDecoder(r.Body).Decode(&d); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err := h.service.CreateAttributeDefinition(r.Context(), &d)
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.AttributeIsExists()) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	jsonBytes, err := json.Marshal(d.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Hand) UpdateAttributeDefinition(w http.ResponseWriter, r *http.Request) {
+	var d internal.AttributeDefinition
+	if err := json.NewDecoder(r.Body).Decode(&d); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err := h.service.UpdateAttributeDefinition(r.Context(), &d)
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.BadRequest()) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errs.Is(err, errors.InvalidAttribute()) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	jsonBytes, err := json.Marshal(d)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Hand) DeleteAttributeDefinition(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if len(vars) == 0 {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	err := h.service.DeleteAttributeDefinition(r.Context(), vars["id"])
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	jsonBytes, err := json.Marshal(internal.AttributeDefinition{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	errs "errors"
+	"net/http"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/gorilla/mux"
+)
+
+func currencyError(err error) bool {
+	return errs.Is(err, errors.BadRequest()) || errs.Is(err, errors.ExchangeRateIsNotExists())
+}
+
+func (h *Hand) convertExpanded(
+	ctx context.Context, employees []internal.EmployeeWithPosition, currency string,
+) ([]internal.EmployeeWithPosition, error) {
+	positions := make([]internal.Position, 0, len(employees))
+	for _, e := range employees {
+		if e.Position != nil {
+			positions = append(positions, *e.Position)
+		}
+	}
+	converted, err := h.service.ConvertPositions(ctx, positions, currency)
+	if err != nil {
+		return nil, err
+	}
+	for i := range employees {
+		if employees[i].Position != nil {
+			employees[i].Position = &converted[0]
+			converted = converted[1:]
+		}
+	}
+	return employees, nil
+}
+
+func (h *Hand) GetExchangeRates(w http.ResponseWriter, r *http.Request) {
+	rates, err := h.service.GetExchangeRates(r.Context(), r.URL.Query().Get("currency"))
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.BadRequest()) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	jsonBytes, err := json.Marshal(rates)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Hand) SetExchangeRate(w http.ResponseWriter, r *http.Request) {
+	var rate internal.ExchangeRate
+	if err := json.NewDecoder(r.Body).Decode(&rate); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err := h.service.SetExchangeRate(r.Context(), &rate)
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	jsonBytes, err := json.Marshal(rate)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Hand) DeleteExchangeRate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if len(vars) == 0 {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	err := h.service.DeleteExchangeRate(r.Context(), vars["id"])
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	jsonBytes, err := json.Marshal(internal.ExchangeRate{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"encoding/json"
+	errs "errors"
+	"net/http"
+	"strconv"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/gorilla/mux"
+)
+
+func (h *Hand) GetDepartments(w http.ResponseWriter, r *http.Request) {
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	departments, err := h.service.GetDepartments(r.Context(), limit, offset)
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.BadRequest()) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errs.Is(err, errors.NotFound()) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	jsonBytes, err := json.Marshal(departments)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Hand) GetDepartment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if len(vars) == 0 {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	d, err := h.service.GetDepartment(r.Context(), vars["id"])
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.NotFound()) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	jsonBytes, err := json.Marshal(d)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Hand) GetDepartmentEmployees(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if len(vars) == 0 {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	view, err := parseEmployeeView(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	employees, err := h.service.GetDepartmentEmployees(r.Context(), vars["id"])
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.NotFound()) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	body, err := h.renderEmployees(r.Context(), view, employees)
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if currencyError(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	jsonBytes, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Hand) CreateDepartment(w http.ResponseWriter, r *http.Request) {
+	var d internal.Department
+	if err := json.NewDecoder(r.Body).Decode(&d); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err := h.service.CreateDepartment(r.Context(), &d)
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.DepartmentIsExists()) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	jsonBytes, err := json.Marshal(d.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Hand) UpdateDepartment(w http.ResponseWriter, r *http.Request) {
+	var d internal.Department
+	if err := json.NewDecoder(r.Body).Decode(&d); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err := h.service.UpdateDepartment(r.Context(), &d)
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.BadRequest()) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errs.Is(err, errors.DepartmentIsExists()) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	jsonBytes, err := json.Marshal(d)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Hand) DeleteDepartment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if len(vars) == 0 {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	err := h.service.DeleteDepartment(r.Context(), vars["id"])
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.DepartmentIsNotEmpty()) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	jsonBytes, err := json.Marshal(internal.Department{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
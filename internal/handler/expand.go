@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/errors"
+)
+
+const expandPosition = "position"
+
+var employeeFields = map[string]bool{ // nolint: gochecknoglobals
+	"id":              true,
+	"first_name":      true,
+	"last_name":       true,
+	"position_id":     true,
+	"manager_id":      true,
+	"status":          true,
+	"email":           true,
+	"phone":           true,
+	"date_of_birth":   true,
+	"hire_date":       true,
+	"employment_type": true,
+	"work_location":   true,
+	"employee_number": true,
+	"attributes":      true,
+	"position":        true,
+}
+
+type employeeView struct {
+	expand   bool
+	fields   []string
+	currency string
+}
+
+func parseEmployeeView(r *http.Request) (employeeView, error) {
+	v := employeeView{currency: r.URL.Query().Get("currency")}
+	if v.currency != "" && !internal.ValidCurrency(v.currency) {
+		return employeeView{}, errors.BadRequest()
+	}
+	for _, name := range splitList(r.URL.Query().Get("expand")) {
+		if name != expandPosition {
+			return employeeView{}, errors.BadRequest()
+		}
+		v.expand = true
+	}
+	for _, name := range splitList(r.URL.Query().Get("fields")) {
+		if !employeeFields[name] {
+			return employeeView{}, errors.BadRequest()
+		}
+		if name == expandPosition {
+			v.expand = true
+		}
+		v.fields = append(v.fields, name)
+	}
+	return v, nil
+}
+
+func splitList(s string) []string {
+	var answer []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			answer = append(answer, part)
+		}
+	}
+	return answer
+}
+
+type expandedEmployee struct {
+	internal.Employee
+	Position interface{} `json:"position,omitempty"`
+}
+
+func (h *Hand) renderEmployees(ctx context.Context, v employeeView, employees []internal.Employee) ([]interface{}, error) {
+	answer := make([]interface{}, 0, len(employees))
+	if !v.expand {
+		for _, e := range employees {
+			answer = append(answer, e)
+		}
+		return selectFields(answer, v.fields)
+	}
+	expanded, err := h.service.ExpandEmployees(ctx, employees)
+	if err != nil {
+		return nil, err
+	}
+	if v.currency != "" {
+		expanded, err = h.convertExpanded(ctx, expanded, v.currency)
+		if err != nil {
+			return nil, err
+		}
+	}
+	salary := auth.Can(ctx, auth.PermSalaryRead)
+	for _, e := range expanded {
+		item := expandedEmployee{Employee: e.Employee}
+		switch {
+		case e.Position == nil:
+		case salary:
+			item.Position = e.Position
+		default:
+			item.Position = redactPosition(*e.Position)
+		}
+		answer = append(answer, item)
+	}
+	return selectFields(answer, v.fields)
+}
+
+func selectFields(items []interface{}, fields []string) ([]interface{}, error) {
+	if len(fields) == 0 {
+		return items, nil
+	}
+	for i, item := range items {
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		var all map[string]json.RawMessage
+		err = json.Unmarshal(raw, &all)
+		if err != nil {
+			return nil, err
+		}
+		selected := make(map[string]json.RawMessage, len(fields))
+		for _, name := range fields {
+			if value, ok := all[name]; ok {
+				selected[name] = value
+			}
+		}
+		items[i] = selected
+	}
+	return items, nil
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
 	"github.com/VTerenya/employees/internal/errors"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -34,6 +35,10 @@ func (h *Hand) GetPositions(w http.ResponseWriter, r *http.Request) {
 	}
 	positions, err := h.service.GetPositions(r.Context(), limit, offset)
 	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		if errs.Is(err, errors.NotFound()) {
 			http.Error(w, err.Error(), http.StatusNotFound)
 			return
@@ -41,7 +46,22 @@ func (h *Hand) GetPositions(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	jsonBytes, err := json.Marshal(positions)
+	if currency := r.URL.Query().Get("currency"); currency != "" {
+		positions, err = h.service.ConvertPositions(r.Context(), positions, currency)
+		if err != nil {
+			if currencyError(err) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	var body interface{} = positions
+	if !auth.Can(r.Context(), auth.PermSalaryRead) {
+		body = redactPositions(positions)
+	}
+	jsonBytes, err := json.Marshal(body)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -63,8 +83,21 @@ func (h *Hand) GetEmployees(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	employees, err := h.service.GetEmployees(r.Context(), limit, offset)
+	view, err := parseEmployeeView(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	employees, err := h.service.GetEmployees(r.Context(), limit, offset, employeeFilter(r))
 	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.BadRequest()) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		if errs.Is(err, errors.NotFound()) {
 			http.Error(w, err.Error(), http.StatusNotFound)
 			return
@@ -72,7 +105,20 @@ func (h *Hand) GetEmployees(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	jsonBytes, err := json.Marshal(employees)
+	body, err := h.renderEmployees(r.Context(), view, employees)
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if currencyError(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	jsonBytes, err := json.Marshal(body)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -91,6 +137,10 @@ func (h *Hand) GetPosition(w http.ResponseWriter, r *http.Request) {
 	}
 	p, err := h.service.GetPosition(r.Context(), vars["id"])
 	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		if errs.Is(err, errors.NotFound()) {
 			http.Error(w, err.Error(), http.StatusNotFound)
 			return
@@ -98,7 +148,23 @@ func (h *Hand) GetPosition(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	jsonBytes, err := json.Marshal(p)
+	if currency := r.URL.Query().Get("currency"); currency != "" {
+		converted, err := h.service.ConvertPositions(r.Context(), []internal.Position{p}, currency)
+		if err != nil {
+			if currencyError(err) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		p = converted[0]
+	}
+	var body interface{} = p
+	if !auth.Can(r.Context(), auth.PermSalaryRead) {
+		body = redactPosition(p)
+	}
+	jsonBytes, err := json.Marshal(body)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -115,8 +181,17 @@ func (h *Hand) GetEmployee(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "bad request", http.StatusBadRequest)
 		return
 	}
+	view, err := parseEmployeeView(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	e, err := h.service.GetEmployee(r.Context(), vars["id"])
 	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		if errs.Is(err, errors.NotFound()) {
 			http.Error(w, err.Error(), http.StatusNotFound)
 			return
@@ -124,7 +199,20 @@ func (h *Hand) GetEmployee(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	jsonBytes, err := json.Marshal(e)
+	body, err := h.renderEmployees(r.Context(), view, []internal.Employee{e})
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if currencyError(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	jsonBytes, err := json.Marshal(body[0])
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -147,6 +235,10 @@ func (h *Hand) CreatePosition(w http.ResponseWriter, r *http.Request) {
 	}
 	err := h.service.CreatePosition(r.Context(), &p)
 	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -167,12 +259,20 @@ func (h *Hand) CreateEmployee(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	if e.LasName == "" || e.FirstName == "" || e.PositionID == uuid.Nil {
+	if e.LastName == "" || e.FirstName == "" || e.PositionID == uuid.Nil {
 		http.Error(w, errors.BadRequest().Error(), http.StatusBadRequest)
 		return
 	}
 	err := h.service.CreateEmployee(r.Context(), &e)
 	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if profileConflict(err) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -195,7 +295,13 @@ func (h *Hand) UpdatePosition(w http.ResponseWriter, r *http.Request) {
 	}
 	err := h.service.UpdatePosition(r.Context(), &p)
 	if err != nil {
-		if errs.Is(err, errors.BadRequest()) {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.BadRequest()) || errs.Is(err, errors.DepartmentIsNotExists()) ||
+			errs.Is(err, errors.InvalidAttribute()) || errs.Is(err, errors.PayGradeIsNotExists()) ||
+			errs.Is(err, errors.SalaryOutOfBand()) || errs.Is(err, errors.ExchangeRateIsNotExists()) {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
@@ -221,12 +327,22 @@ func (h *Hand) UpdateEmployee(w http.ResponseWriter, r *http.Request) {
 	}
 	err := h.service.UpdateEmployee(r.Context(), &e)
 	if err != nil {
-		if errs.Is(err, errors.BadRequest()) {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if profileConflict(err) || errs.Is(err, errors.PositionNeedsTransfer()) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if errs.Is(err, errors.BadRequest()) || errs.Is(err, errors.InvalidAttribute()) {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		if errs.Is(err, errors.PositionIsNotExists()) {
+		if errs.Is(err, errors.PositionIsNotExists()) ||
+			errs.Is(err, errors.ManagerIsNotExists()) || errs.Is(err, errors.ManagerCycle()) {
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
@@ -250,6 +366,10 @@ func (h *Hand) DeletePosition(w http.ResponseWriter, r *http.Request) {
 	}
 	err := h.service.DeletePosition(r.Context(), vars["id"])
 	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
@@ -272,6 +392,14 @@ func (h *Hand) DeleteEmployee(w http.ResponseWriter, r *http.Request) {
 	}
 	err := h.service.DeleteEmployee(r.Context(), vars["id"])
 	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.EmployeeHasReports()) || errs.Is(err, errors.EmployeeIsNotTerminated()) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
@@ -285,3 +413,7 @@ func (h *Hand) DeleteEmployee(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, er.Error(), http.StatusInternalServerError)
 	}
 }
+
+func profileConflict(err error) bool {
+	return errs.Is(err, errors.EmailIsExists()) || errs.Is(err, errors.EmployeeNumberIsExists())
+}
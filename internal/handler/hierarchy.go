@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	errs "errors"
+	"net/http"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/gorilla/mux"
+)
+
+func (h *Hand) GetDirectReports(w http.ResponseWriter, r *http.Request) {
+	h.writeRelatedEmployees(w, r, h.service.GetDirectReports)
+}
+
+func (h *Hand) GetManagementChain(w http.ResponseWriter, r *http.Request) {
+	h.writeRelatedEmployees(w, r, h.service.GetManagementChain)
+}
+
+func (h *Hand) GetReportingSubtree(w http.ResponseWriter, r *http.Request) {
+	h.writeRelatedEmployees(w, r, h.service.GetReportingSubtree)
+}
+
+func (h *Hand) writeRelatedEmployees(w http.ResponseWriter, r *http.Request,
+	fetch func(ctx context.Context, id string) ([]internal.Employee, error)) {
+	vars := mux.Vars(r)
+	if len(vars) == 0 {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	view, err := parseEmployeeView(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	employees, err := fetch(r.Context(), vars["id"])
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.NotFound()) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	body, err := h.renderEmployees(r.Context(), view, employees)
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if currencyError(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	jsonBytes, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	errs "errors"
+	"io"
+	"net/http"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type hireRequest struct {
+	EffectiveDate internal.Date `json:"effective_date"`
+}
+
+func (h *Hand) HireEmployee(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var e internal.Employee
+	var req hireRequest
+	if err := json.Unmarshal(body, &e); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if e.LastName == "" || e.FirstName == "" || e.PositionID == uuid.Nil {
+		http.Error(w, errors.BadRequest().Error(), http.StatusBadRequest)
+		return
+	}
+	err = h.service.HireEmployee(r.Context(), &e, req.EffectiveDate)
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if profileConflict(err) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	jsonBytes, err := json.Marshal(e)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Hand) RestoreEmployee(w http.ResponseWriter, r *http.Request) {
+	var rec internal.EmployeeRecord
+	if err := json.NewDecoder(r.Body).Decode(&rec); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	e := rec.Employee
+	if e.LastName == "" || e.FirstName == "" || e.PositionID == uuid.Nil {
+		http.Error(w, errors.BadRequest().Error(), http.StatusBadRequest)
+		return
+	}
+	err := h.service.RestoreEmployee(r.Context(), &rec)
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if profileConflict(err) || errs.Is(err, errors.EmployeeIsExists()) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	jsonBytes, err := json.Marshal(rec.Employee)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Hand) TransferEmployee(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.TransferEmployee)
+}
+
+func (h *Hand) StartLeave(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.StartLeave)
+}
+
+func (h *Hand) ReturnFromLeave(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.ReturnFromLeave)
+}
+
+func (h *Hand) TerminateEmployee(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.TerminateEmployee)
+}
+
+func (h *Hand) RehireEmployee(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.RehireEmployee)
+}
+
+func (h *Hand) changeStatus(w http.ResponseWriter, r *http.Request,
+	change func(ctx context.Context, id string, c internal.LifecycleChange) (internal.Employee, error)) {
+	vars := mux.Vars(r)
+	if len(vars) == 0 {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	var c internal.LifecycleChange
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	e, err := change(r.Context(), vars["id"], c)
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.NotFound()) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if errs.Is(err, errors.InvalidTransition()) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	jsonBytes, err := json.Marshal(e)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Hand) GetEmployeeEvents(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if len(vars) == 0 {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	events, err := h.service.GetEmployeeEvents(r.Context(), vars["id"])
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.NotFound()) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	jsonBytes, err := json.Marshal(events)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	errs "errors"
+	"net/http"
+	"strconv"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/VTerenya/employees/internal/orgchart"
+)
+
+func (h *Hand) GetOrgChart(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format == "" {
+		format = orgchart.FormatJSON
+	}
+	if !orgchart.ValidFormat(format) {
+		http.Error(w, errors.BadRequest().Error(), http.StatusBadRequest)
+		return
+	}
+	q := internal.OrgChartQuery{EmployeeID: query.Get("employee"), DepartmentID: query.Get("department")}
+	if depth := query.Get("depth"); depth != "" {
+		var err error
+		q.Depth, err = strconv.Atoi(depth)
+		if err != nil {
+			http.Error(w, errors.BadRequest().Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	nodes, err := h.service.OrgChart(r.Context(), q)
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.BadRequest()) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errs.Is(err, errors.NotFound()) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var buf bytes.Buffer
+	switch format {
+	case orgchart.FormatDOT:
+		err = orgchart.WriteDOT(&buf, nodes)
+	case orgchart.FormatMermaid:
+		err = orgchart.WriteMermaid(&buf, nodes)
+	default:
+		err = json.NewEncoder(&buf).Encode(nodes)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", orgchart.ContentType(format))
+	_, er := w.Write(buf.Bytes())
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"github.com/VTerenya/employees/internal"
+	"github.com/google/uuid"
+)
+
+type redactedPosition struct {
+	ID           uuid.UUID           `json:"id"`
+	Name         string              `json:"name"`
+	DepartmentID *uuid.UUID          `json:"department_id,omitempty"`
+	PayGradeID   *uuid.UUID          `json:"pay_grade_id,omitempty"`
+	Attributes   internal.Attributes `json:"attributes,omitempty"`
+}
+
+func redactPosition(p internal.Position) redactedPosition {
+	p = p.Redacted()
+	return redactedPosition{
+		ID:           p.ID,
+		Name:         p.Name,
+		DepartmentID: p.DepartmentID,
+		PayGradeID:   p.PayGradeID,
+		Attributes:   p.Attributes,
+	}
+}
+
+func redactPositions(positions []internal.Position) []redactedPosition {
+	answer := make([]redactedPosition, 0, len(positions))
+	for _, p := range positions {
+		answer = append(answer, redactPosition(p))
+	}
+	return answer
+}
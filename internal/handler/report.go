@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	errs "errors"
+	"net/http"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/VTerenya/employees/internal/report"
+)
+
+func (h *Hand) GetPayrollReport(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format == "" {
+		format = report.FormatJSON
+	}
+	if !report.ValidFormat(format) {
+		http.Error(w, errors.BadRequest().Error(), http.StatusBadRequest)
+		return
+	}
+	payroll, err := h.service.GetPayrollReport(r.Context(), query.Get("currency"))
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if currencyError(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var buf bytes.Buffer
+	if format == report.FormatCSV {
+		err = report.WritePayrollCSV(&buf, payroll)
+	} else {
+		err = json.NewEncoder(&buf).Encode(payroll)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", report.ContentType(format))
+	_, er := w.Write(buf.Bytes())
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func reportRange(r *http.Request) (internal.ReportRange, error) {
+	query := r.URL.Query()
+	q := internal.ReportRange{Interval: query.Get("interval")}
+	for name, date := range map[string]*internal.Date{"from": &q.From, "to": &q.To} {
+		if value := query.Get(name); value != "" {
+			parsed, err := internal.ParseDate(value)
+			if err != nil {
+				return internal.ReportRange{}, errors.BadRequest()
+			}
+			*date = parsed
+		}
+	}
+	return q, nil
+}
+
+func (h *Hand) GetHeadcountReport(w http.ResponseWriter, r *http.Request) {
+	q, err := reportRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	headcount, err := h.service.GetHeadcountReport(r.Context(), q)
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.BadRequest()) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	jsonBytes, err := json.Marshal(headcount)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *Hand) GetTurnoverReport(w http.ResponseWriter, r *http.Request) {
+	q, err := reportRange(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	turnover, err := h.service.GetTurnoverReport(r.Context(), q)
+	if err != nil {
+		if errs.Is(err, errors.Forbidden()) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errs.Is(err, errors.BadRequest()) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	jsonBytes, err := json.Marshal(turnover)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
@@ -10,11 +10,53 @@ type Service interface {
 	CreatePosition(ctx context.Context, p *internal.Position) error
 	CreateEmployee(ctx context.Context, e *internal.Employee) error
 	GetPositions(ctx context.Context, limit, offset int) ([]internal.Position, error)
-	GetEmployees(ctx context.Context, limit, offset int) ([]internal.Employee, error)
+	GetEmployees(ctx context.Context, limit, offset int, filter internal.EmployeeFilter) ([]internal.Employee, error)
 	GetPosition(ctx context.Context, id string) (internal.Position, error)
 	GetEmployee(ctx context.Context, id string) (internal.Employee, error)
+	GetDirectReports(ctx context.Context, id string) ([]internal.Employee, error)
+	GetManagementChain(ctx context.Context, id string) ([]internal.Employee, error)
+	GetReportingSubtree(ctx context.Context, id string) ([]internal.Employee, error)
+	OrgChart(ctx context.Context, q internal.OrgChartQuery) ([]*internal.OrgChartNode, error)
+	HireEmployee(ctx context.Context, e *internal.Employee, date internal.Date) error
+	RestoreEmployee(ctx context.Context, r *internal.EmployeeRecord) error
+	TransferEmployee(ctx context.Context, id string, c internal.LifecycleChange) (internal.Employee, error)
+	StartLeave(ctx context.Context, id string, c internal.LifecycleChange) (internal.Employee, error)
+	ReturnFromLeave(ctx context.Context, id string, c internal.LifecycleChange) (internal.Employee, error)
+	TerminateEmployee(ctx context.Context, id string, c internal.LifecycleChange) (internal.Employee, error)
+	RehireEmployee(ctx context.Context, id string, c internal.LifecycleChange) (internal.Employee, error)
+	GetEmployeeEvents(ctx context.Context, id string) ([]internal.LifecycleEvent, error)
+	ExpandEmployees(ctx context.Context, employees []internal.Employee) ([]internal.EmployeeWithPosition, error)
 	DeletePosition(ctx context.Context, id string) error
 	DeleteEmployee(ctx context.Context, id string) error
 	UpdatePosition(ctx context.Context, p *internal.Position) error
 	UpdateEmployee(ctx context.Context, e *internal.Employee) error
+	CreateDepartment(ctx context.Context, d *internal.Department) error
+	GetDepartments(ctx context.Context, limit, offset int) ([]internal.Department, error)
+	GetDepartment(ctx context.Context, id string) (internal.Department, error)
+	GetDepartmentEmployees(ctx context.Context, id string) ([]internal.Employee, error)
+	UpdateDepartment(ctx context.Context, d *internal.Department) error
+	DeleteDepartment(ctx context.Context, id string) error
+	GetExchangeRates(ctx context.Context, currency string) ([]internal.ExchangeRate, error)
+	SetExchangeRate(ctx context.Context, r *internal.ExchangeRate) error
+	DeleteExchangeRate(ctx context.Context, id string) error
+	ConvertPositions(ctx context.Context, positions []internal.Position, currency string) ([]internal.Position, error)
+	ConvertPayGrades(ctx context.Context, grades []internal.PayGrade, currency string) ([]internal.PayGrade, error)
+	ConvertCompaRatios(ctx context.Context, ratios []internal.CompaRatio, currency string) ([]internal.CompaRatio, error)
+	CreatePayGrade(ctx context.Context, g *internal.PayGrade) error
+	GetPayGrades(ctx context.Context) ([]internal.PayGrade, error)
+	GetPayGrade(ctx context.Context, id string) (internal.PayGrade, error)
+	UpdatePayGrade(ctx context.Context, g *internal.PayGrade) error
+	DeletePayGrade(ctx context.Context, id string) error
+	GetCompaRatios(ctx context.Context) ([]internal.CompaRatio, error)
+	GetPayrollReport(ctx context.Context, currency string) (internal.PayrollReport, error)
+	GetHeadcountReport(ctx context.Context, q internal.ReportRange) (internal.HeadcountReport, error)
+	GetTurnoverReport(ctx context.Context, q internal.ReportRange) (internal.TurnoverReport, error)
+	GetAttributeDefinitions(ctx context.Context, entity string) ([]internal.AttributeDefinition, error)
+	CreateAttributeDefinition(ctx context.Context, d *internal.AttributeDefinition) error
+	UpdateAttributeDefinition(ctx context.Context, d *internal.AttributeDefinition) error
+	DeleteAttributeDefinition(ctx context.Context, id string) error
+	CreateAPIKey(ctx context.Context, k *internal.APIKey) (string, error)
+	GetAPIKeys(ctx context.Context) ([]internal.APIKey, error)
+	RotateAPIKey(ctx context.Context, id string) (internal.APIKey, string, error)
+	RevokeAPIKey(ctx context.Context, id string) error
 }
@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	IntervalMonth   = "month"
+	IntervalQuarter = "quarter"
+	IntervalYear    = "year"
+)
+
+func ValidInterval(interval string) bool {
+	switch interval {
+	case IntervalMonth, IntervalQuarter, IntervalYear:
+		return true
+	}
+	return false
+}
+
+type ReportRange struct {
+	From     Date
+	To       Date
+	Interval string
+}
+
+type PositionHeadcount struct {
+	PositionID   uuid.UUID `json:"position_id"`
+	PositionName string    `json:"position_name,omitempty"`
+	Headcount    int       `json:"headcount"`
+	Hires        int       `json:"hires"`
+	Terminations int       `json:"terminations"`
+}
+
+type HeadcountPeriod struct {
+	Start        Date                `json:"start"`
+	End          Date                `json:"end"`
+	Headcount    int                 `json:"headcount"`
+	Hires        int                 `json:"hires"`
+	Terminations int                 `json:"terminations"`
+	Positions    []PositionHeadcount `json:"positions"`
+}
+
+type HeadcountReport struct {
+	From     Date              `json:"from"`
+	To       Date              `json:"to"`
+	Interval string            `json:"interval"`
+	Periods  []HeadcountPeriod `json:"periods"`
+}
+
+type TurnoverStats struct {
+	HeadcountStart    int             `json:"headcount_start"`
+	HeadcountEnd      int             `json:"headcount_end"`
+	AverageHeadcount  decimal.Decimal `json:"average_headcount"`
+	Hires             int             `json:"hires"`
+	Terminations      int             `json:"terminations"`
+	TurnoverRate      decimal.Decimal `json:"turnover_rate"`
+	AverageTenureDays decimal.Decimal `json:"average_tenure_days"`
+}
+
+type PositionTurnover struct {
+	PositionID   uuid.UUID `json:"position_id"`
+	PositionName string    `json:"position_name,omitempty"`
+	TurnoverStats
+}
+
+type TurnoverReport struct {
+	From     Date   `json:"from"`
+	To       Date   `json:"to"`
+	Interval string `json:"interval"`
+	TurnoverStats
+	Positions []PositionTurnover `json:"positions"`
+}
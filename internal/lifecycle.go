@@ -0,0 +1,62 @@
+package internal
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusActive     = "active"
+	StatusOnLeave    = "on_leave"
+	StatusTerminated = "terminated"
+)
+
+const (
+	EventHire      = "hire"
+	EventTransfer  = "transfer"
+	EventLeave     = "leave"
+	EventReturn    = "return"
+	EventTerminate = "terminate"
+	EventRehire    = "rehire"
+)
+
+func ValidStatus(status string) bool {
+	switch status {
+	case StatusActive, StatusOnLeave, StatusTerminated:
+		return true
+	}
+	return false
+}
+
+func ValidEventType(eventType string) bool {
+	switch eventType {
+	case EventHire, EventTransfer, EventLeave, EventReturn, EventTerminate, EventRehire:
+		return true
+	}
+	return false
+}
+
+type LifecycleEvent struct {
+	ID             uuid.UUID  `json:"id"`
+	EmployeeID     uuid.UUID  `json:"employee_id"`
+	Type           string     `json:"type"`
+	EffectiveDate  Date       `json:"effective_date"`
+	FromPositionID *uuid.UUID `json:"from_position_id,omitempty"`
+	ToPositionID   *uuid.UUID `json:"to_position_id,omitempty"`
+	Reason         string     `json:"reason,omitempty"`
+	RecordedAt     time.Time  `json:"recorded_at"`
+}
+
+type LifecycleChange struct {
+	PositionID    *uuid.UUID `json:"position_id,omitempty"`
+	EffectiveDate Date       `json:"effective_date"`
+	Reason        string     `json:"reason,omitempty"`
+}
+
+// EmployeeRecord is an employee together with its lifecycle history, as an
+// export writes it and a restore reads it back.
+type EmployeeRecord struct {
+	Employee Employee         `json:"employee"`
+	Events   []LifecycleEvent `json:"events,omitempty"`
+}
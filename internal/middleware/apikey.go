@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/requestctx"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	APIKeyHeader = "X-API-Key"
+	apiKeyScheme = "apikey "
+)
+
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, secret string) (internal.APIKey, error)
+}
+
+func apiKeyFromRequest(r *http.Request) string {
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		return key
+	}
+	header := r.Header.Get("Authorization")
+	if len(header) > len(apiKeyScheme) && strings.EqualFold(header[:len(apiKeyScheme)], apiKeyScheme) {
+		return strings.TrimSpace(header[len(apiKeyScheme):])
+	}
+	return ""
+}
+
+func NewAPIKeyMiddleware(authenticator APIKeyAuthenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			secret := apiKeyFromRequest(r)
+			if secret == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			k, err := authenticator.AuthenticateAPIKey(r.Context(), secret)
+			if err != nil {
+				requestctx.Logger(r.Context()).WithError(err).Warn("rejected api key")
+				w.Header().Set("WWW-Authenticate", "ApiKey")
+				WriteProblem(w, r, http.StatusUnauthorized, "invalid api key")
+				return
+			}
+			id := auth.APIKeyIdentity(k)
+			requestctx.AddLogFields(r.Context(), logrus.Fields{"user": id.Subject, "api_key": k.Name})
+			ctx := auth.WithIdentity(r.Context(), id)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/google/uuid"
+)
+
+type fakeAPIKeys map[string]internal.APIKey
+
+func (f fakeAPIKeys) AuthenticateAPIKey(_ context.Context, secret string) (internal.APIKey, error) {
+	k, ok := f[secret]
+	if !ok {
+		return internal.APIKey{}, errors.Unauthorized()
+	}
+	return k, nil
+}
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	reader := internal.APIKey{ID: uuid.New(), Name: "reader", Scopes: []string{auth.PermEmployeesRead}}
+	keys := fakeAPIKeys{"emp_reader": reader}
+	h := NewAPIKeyMiddleware(keys)(NewAnonymousMiddleware()(
+		RequirePermission(auth.PermEmployeesRead)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, _ := auth.FromContext(r.Context())
+			w.Header().Set("X-Subject", id.Subject)
+			w.WriteHeader(http.StatusNoContent)
+		}))))
+	writer := NewAPIKeyMiddleware(keys)(RequirePermission(auth.PermEmployeesWrite)(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) })))
+	for _, tt := range []struct {
+		name          string
+		handler       http.Handler
+		header, value string
+		code          int
+	}{
+		{"X-API-Key", h, APIKeyHeader, "emp_reader", http.StatusNoContent},
+		{"ApiKey scheme", h, "Authorization", "ApiKey emp_reader", http.StatusNoContent},
+		{"unknown key", h, APIKeyHeader, "emp_unknown", http.StatusUnauthorized},
+		{"unknown key in scheme", h, "Authorization", "apikey emp_unknown", http.StatusUnauthorized},
+		{"missing scope", writer, APIKeyHeader, "emp_reader", http.StatusForbidden},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/employees", nil)
+		req.Header.Set(tt.header, tt.value)
+		w := httptest.NewRecorder()
+		tt.handler.ServeHTTP(w, req)
+		if w.Code != tt.code {
+			t.Errorf("%s: code %d, want %d", tt.name, w.Code, tt.code)
+			continue
+		}
+		switch w.Code {
+		case http.StatusNoContent:
+			if w.Header().Get("X-Subject") != "apikey:"+reader.ID.String() {
+				t.Errorf("%s: subject %q", tt.name, w.Header().Get("X-Subject"))
+			}
+		case http.StatusUnauthorized:
+			if w.Header().Get("WWW-Authenticate") != "ApiKey" {
+				t.Errorf("%s: WWW-Authenticate %q", tt.name, w.Header().Get("WWW-Authenticate"))
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/requestctx"
+	"github.com/sirupsen/logrus"
+)
+
+const bearerPrefix = "bearer "
+
+func NewAuthMiddleware(verifier *auth.JWTVerifier, policy *auth.Policy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := auth.FromContext(r.Context()); ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			header := r.Header.Get("Authorization")
+			if len(header) <= len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+				unauthorized(w, r, "missing bearer token")
+				return
+			}
+			claims, err := verifier.Verify(strings.TrimSpace(header[len(bearerPrefix):]))
+			if err != nil {
+				requestctx.Logger(r.Context()).WithError(err).Warn("rejected bearer token")
+				unauthorized(w, r, "invalid bearer token")
+				return
+			}
+			requestctx.AddLogFields(r.Context(), logrus.Fields{"user": claims.Subject, "roles": claims.Roles})
+			ctx := auth.WithIdentity(r.Context(), auth.JWTIdentity(claims, policy))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// NewAnonymousMiddleware stands in for NewAuthMiddleware when authentication
+// is disabled: callers not identified otherwise get every permission.
+func NewAnonymousMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := auth.FromContext(r.Context()); ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), auth.AnonymousIdentity())))
+		})
+	}
+}
+
+func unauthorized(w http.ResponseWriter, r *http.Request, detail string) {
+	w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+	WriteProblem(w, r, http.StatusUnauthorized, detail)
+}
+
+func RequirePermission(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !auth.Can(r.Context(), permission) {
+				WriteProblem(w, r, http.StatusForbidden, "missing permission "+permission)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
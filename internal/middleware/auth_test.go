@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/VTerenya/employees/internal/auth"
+)
+
+func hs256(t *testing.T, secret []byte, claims map[string]interface{}) string {
+	t.Helper()
+	c, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString(c)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed)) // nolint: errcheck
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	secret := []byte("secret")
+	keys := auth.NewKeySet()
+	keys.AddHMAC("", secret)
+	policy := auth.NewPolicy(map[string][]string{"viewer": {auth.PermEmployeesRead}})
+	h := NewAuthMiddleware(auth.NewJWTVerifier(keys, "", "", 0), policy)(
+		RequirePermission(auth.PermEmployeesRead)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Subject", auth.Subject(r.Context()))
+			w.WriteHeader(http.StatusNoContent)
+		})))
+	exp := time.Now().Add(time.Hour).Unix()
+	viewer := hs256(t, secret, map[string]interface{}{"sub": "ann", "exp": exp, "roles": []string{"viewer"}})
+	stranger := hs256(t, secret, map[string]interface{}{"sub": "bob", "exp": exp, "roles": []string{"other"}})
+	expired := hs256(t, secret, map[string]interface{}{"sub": "ann", "exp": time.Now().Add(-time.Hour).Unix()})
+	forged := hs256(t, []byte("guess"), map[string]interface{}{"sub": "ann", "exp": exp, "roles": []string{"viewer"}})
+	for _, tt := range []struct {
+		name          string
+		authorization string
+		code          int
+	}{
+		{"valid token", "Bearer " + viewer, http.StatusNoContent},
+		{"lower case scheme", "bearer " + viewer, http.StatusNoContent},
+		{"missing header", "", http.StatusUnauthorized},
+		{"basic scheme", "Basic YW5uOnB3", http.StatusUnauthorized},
+		{"expired token", "Bearer " + expired, http.StatusUnauthorized},
+		{"forged token", "Bearer " + forged, http.StatusUnauthorized},
+		{"role without permission", "Bearer " + stranger, http.StatusForbidden},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/employees", nil)
+		if tt.authorization != "" {
+			req.Header.Set("Authorization", tt.authorization)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != tt.code {
+			t.Errorf("%s: code %d, want %d", tt.name, w.Code, tt.code)
+			continue
+		}
+		challenge := w.Header().Get("WWW-Authenticate")
+		switch tt.code {
+		case http.StatusNoContent:
+			if w.Header().Get("X-Subject") != "ann" {
+				t.Errorf("%s: subject %q", tt.name, w.Header().Get("X-Subject"))
+			}
+		case http.StatusUnauthorized:
+			if challenge != `Bearer error="invalid_token"` {
+				t.Errorf("%s: WWW-Authenticate %q", tt.name, challenge)
+			}
+		case http.StatusForbidden:
+			if challenge != "" {
+				t.Errorf("%s: WWW-Authenticate %q on 403", tt.name, challenge)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+				t.Errorf("%s: content type %q", tt.name, ct)
+			}
+		}
+	}
+}
+
+func TestRequirePermissionWithoutIdentity(t *testing.T) {
+	h := RequirePermission(auth.PermEmployeesRead)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/employees", nil))
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("code %d", w.Code)
+	}
+}
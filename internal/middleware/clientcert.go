@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/requestctx"
+	"github.com/sirupsen/logrus"
+)
+
+func NewClientCertMiddleware(roles map[string][]string, policy *auth.Policy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			cert := r.TLS.VerifiedChains[0][0]
+			id, ok := auth.CertificateIdentity(cert, roles, policy)
+			if !ok {
+				requestctx.Logger(r.Context()).WithField("client_cert", cert.Subject.String()).Debug("client certificate has no roles")
+				next.ServeHTTP(w, r)
+				return
+			}
+			requestctx.AddLogFields(r.Context(), logrus.Fields{"user": id.Subject, "client_cert": cert.Subject.String()})
+			ctx := auth.WithIdentity(r.Context(), id)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
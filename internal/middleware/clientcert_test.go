@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VTerenya/employees/internal/auth"
+)
+
+func TestClientCertMiddleware(t *testing.T) {
+	policy := auth.NewPolicy(map[string][]string{"staff": {auth.PermEmployeesRead}})
+	roles := map[string][]string{"payroll": {"staff"}}
+	for _, tt := range []struct {
+		commonName string
+		identity   bool
+	}{
+		{"payroll", true},
+		{"stranger", false},
+	} {
+		var got auth.Identity
+		var ok bool
+		h := NewClientCertMiddleware(roles, policy)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			got, ok = auth.FromContext(r.Context())
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/employees", nil)
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: tt.commonName}}
+		req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		if ok != tt.identity {
+			t.Fatalf("%s: identity set = %v, want %v", tt.commonName, ok, tt.identity)
+		}
+		if ok && got.Subject != tt.commonName {
+			t.Fatalf("%s: subject = %q", tt.commonName, got.Subject)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/VTerenya/employees/internal/config"
+)
+
+type cors struct {
+	origins     map[string]bool
+	anyOrigin   bool
+	methods     map[string]bool
+	headers     map[string]bool
+	methodsList string
+	headersList string
+	exposed     string
+	credentials bool
+	maxAge      string
+}
+
+func toSet(values []string, normalize func(string) string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[normalize(strings.TrimSpace(v))] = true
+	}
+	return set
+}
+
+func newCORS(cfg config.CORS) *cors {
+	c := &cors{
+		origins:     toSet(cfg.AllowedOrigins, strings.ToLower),
+		methods:     toSet(cfg.AllowedMethods, strings.ToUpper),
+		headers:     toSet(cfg.AllowedHeaders, http.CanonicalHeaderKey),
+		methodsList: strings.Join(cfg.AllowedMethods, ", "),
+		headersList: strings.Join(cfg.AllowedHeaders, ", "),
+		exposed:     strings.Join(cfg.ExposedHeaders, ", "),
+		credentials: cfg.AllowCredentials,
+	}
+	c.anyOrigin = c.origins["*"]
+	if cfg.MaxAgeSeconds > 0 {
+		c.maxAge = strconv.Itoa(cfg.MaxAgeSeconds)
+	}
+	return c
+}
+
+func (c *cors) originAllowed(origin string) bool {
+	return c.anyOrigin || c.origins[strings.ToLower(origin)]
+}
+
+func (c *cors) headersAllowed(requested string) bool {
+	for _, h := range strings.Split(requested, ",") {
+		h = strings.TrimSpace(h)
+		if h != "" && !c.headers[http.CanonicalHeaderKey(h)] {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *cors) setOrigin(h http.Header, origin string) {
+	if c.anyOrigin && !c.credentials {
+		h.Set("Access-Control-Allow-Origin", "*")
+	} else {
+		h.Set("Access-Control-Allow-Origin", origin)
+	}
+	if c.credentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func (c *cors) preflight(w http.ResponseWriter, r *http.Request, origin string) {
+	method := r.Header.Get("Access-Control-Request-Method")
+	if !c.originAllowed(origin) || !c.methods[strings.ToUpper(method)] ||
+		!c.headersAllowed(r.Header.Get("Access-Control-Request-Headers")) {
+		WriteProblem(w, r, http.StatusForbidden, "cors preflight rejected")
+		return
+	}
+	h := w.Header()
+	c.setOrigin(h, origin)
+	h.Set("Access-Control-Allow-Methods", c.methodsList)
+	if c.headersList != "" {
+		h.Set("Access-Control-Allow-Headers", c.headersList)
+	}
+	if c.maxAge != "" {
+		h.Set("Access-Control-Max-Age", c.maxAge)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func NewCORSMiddleware(cfg config.CORS) func(http.Handler) http.Handler {
+	c := newCORS(cfg)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Add("Vary", "Origin")
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				w.Header().Add("Vary", "Access-Control-Request-Method")
+				w.Header().Add("Vary", "Access-Control-Request-Headers")
+				c.preflight(w, r, origin)
+				return
+			}
+			if c.originAllowed(origin) {
+				c.setOrigin(w.Header(), origin)
+				if c.exposed != "" {
+					w.Header().Set("Access-Control-Expose-Headers", c.exposed)
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func NewSecurityHeadersMiddleware(cfg config.SecurityHeaders) func(http.Handler) http.Handler {
+	hsts := ""
+	if cfg.HSTSMaxAgeSeconds > 0 {
+		hsts = "max-age=" + strconv.Itoa(cfg.HSTSMaxAgeSeconds) + "; includeSubDomains"
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
+			h.Set("X-Content-Type-Options", "nosniff")
+			h.Set("X-Frame-Options", "DENY")
+			h.Set("Referrer-Policy", "no-referrer")
+			if cfg.ContentSecurityPolicy != "" {
+				h.Set("Content-Security-Policy", cfg.ContentSecurityPolicy)
+			}
+			if r.TLS != nil && hsts != "" {
+				h.Set("Strict-Transport-Security", hsts)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/VTerenya/employees/internal/requestctx"
+	"github.com/sirupsen/logrus"
+)
+
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+func (r *responseRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (r *responseRecorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+func NewLogMiddleware(sampleRate float64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			fields := logrus.Fields{
+				requestctx.CorrelationIDKey: requestctx.CorrelationID(r.Context()),
+				"method":                    r.Method,
+				"path":                      r.URL.Path,
+				"remote_addr":               r.RemoteAddr,
+				"host":                      r.Host,
+				"route":                     routeTemplate(r),
+			}
+			ctx := requestctx.WithLogger(r.Context(), logrus.NewEntry(logrus.StandardLogger()).WithFields(fields))
+			rec := &responseRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r.WithContext(ctx))
+			status := rec.Status()
+			if status < http.StatusInternalServerError && !sampled(sampleRate) {
+				return
+			}
+			requestctx.Logger(ctx).WithFields(logrus.Fields{
+				"type":        "access log",
+				"status":      status,
+				"bytes":       rec.bytes,
+				"duration_ms": float64(time.Since(start).Microseconds()) / 1000,
+			}).Info()
+		})
+	}
+}
+
+func sampled(rate float64) bool {
+	if rate >= 1 {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+	return rand.Float64() < rate // nolint: gosec
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VTerenya/employees/internal/requestctx"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestLogMiddlewareCarriesCorrelationID(t *testing.T) {
+	hook := test.NewGlobal()
+	defer logrus.StandardLogger().ReplaceHooks(make(logrus.LevelHooks))
+
+	h := IDMiddleware(NewLogMiddleware(1)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestctx.AddLogFields(r.Context(), logrus.Fields{"user": "ann"})
+		requestctx.Logger(r.Context()).Info("handled")
+		w.WriteHeader(http.StatusTeapot)
+	})))
+	r := httptest.NewRequest(http.MethodGet, "/positions", nil)
+	r.Header.Set(CorrelationIDHeader, "corr-2")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	entries := hook.AllEntries()
+	if len(entries) != 2 {
+		t.Fatalf("logged %d entries, want the handler's and the access log", len(entries))
+	}
+	for _, e := range entries {
+		if e.Data[requestctx.CorrelationIDKey] != "corr-2" || e.Data["user"] != "ann" {
+			t.Errorf("entry %q has fields %v", e.Message, e.Data)
+		}
+	}
+	access := entries[1].Data
+	if access["type"] != "access log" || access["status"] != http.StatusTeapot || access["path"] != "/positions" {
+		t.Fatalf("access log fields = %v", access)
+	}
+}
@@ -1,48 +1,44 @@
 package middleware
 
 import (
-	"context"
 	"net/http"
-	"time"
+	"regexp"
 
+	"github.com/VTerenya/employees/internal/requestctx"
 	"github.com/google/uuid"
-	"github.com/sirupsen/logrus"
 )
 
-func AccessLogMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		next.ServeHTTP(w, r)
-		logrus.WithFields(logrus.Fields{
-			"type":        "access log",
-			"method":      r.Method,
-			"remote_addr": r.RemoteAddr,
-			"host":        r.Host,
-		}).Info()
-	})
-}
+const (
+	RequestIDHeader        = "X-Request-ID"
+	CorrelationIDHeader    = "X-Correlation-ID"
+	maxCorrelationIDLength = 128
+)
+
+var correlationIDPattern = regexp.MustCompile(`^[A-Za-z0-9._\-]+$`) // nolint: gochecknoglobals
 
-func TimeLogMiddleware(next http.Handler) http.Handler {
+func IDMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
+		id, ok := incomingCorrelationID(r)
+		if !ok {
+			id = uuid.New().String()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		w.Header().Set(CorrelationIDHeader, id)
+		r = r.WithContext(requestctx.WithCorrelationID(r.Context(), id))
 		next.ServeHTTP(w, r)
-		duration := time.Since(start)
-		logrus.WithFields(logrus.Fields{
-			"type":      "time log",
-			"work_time": duration,
-		}).Info()
 	})
 }
 
-const CorrelationID = "correlation_id"
+func incomingCorrelationID(r *http.Request) (string, bool) {
+	for _, header := range []string{RequestIDHeader, CorrelationIDHeader} {
+		id := r.Header.Get(header)
+		if ValidCorrelationID(id) {
+			return id, true
+		}
+	}
+	return "", false
+}
 
-func IDMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-		id := uuid.New()
-		//revive:disable
-		ctx = context.WithValue(ctx, CorrelationID, id.String()) //nolint:staticcheck
-		//revive:enable
-		r = r.WithContext(ctx)
-		next.ServeHTTP(w, r)
-	})
+func ValidCorrelationID(id string) bool {
+	return id != "" && len(id) <= maxCorrelationIDLength && correlationIDPattern.MatchString(id)
 }
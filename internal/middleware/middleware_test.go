@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VTerenya/employees/internal/requestctx"
+	"github.com/google/uuid"
+)
+
+func TestIDMiddleware(t *testing.T) {
+	var seen string
+	h := IDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = requestctx.CorrelationID(r.Context())
+	}))
+	for _, tt := range []struct {
+		headers map[string]string
+		want    string
+	}{
+		{map[string]string{RequestIDHeader: "req-1"}, "req-1"},
+		{map[string]string{CorrelationIDHeader: "corr-2"}, "corr-2"},
+		{map[string]string{RequestIDHeader: "req-1", CorrelationIDHeader: "corr-2"}, "req-1"},
+		{map[string]string{RequestIDHeader: "bad id!", CorrelationIDHeader: "corr-2"}, "corr-2"},
+		{map[string]string{CorrelationIDHeader: "bad id!"}, ""},
+		{nil, ""},
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/positions", nil)
+		for name, value := range tt.headers {
+			r.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		want := tt.want
+		if want == "" {
+			if _, err := uuid.Parse(seen); err != nil {
+				t.Fatalf("%v: generated id %q is not a uuid", tt.headers, seen)
+			}
+			want = seen
+		}
+		if seen != want {
+			t.Errorf("%v: handler saw %q, want %q", tt.headers, seen, want)
+		}
+		for _, header := range []string{RequestIDHeader, CorrelationIDHeader} {
+			if got := w.Header().Get(header); got != want {
+				t.Errorf("%v: response %s = %q, want %q", tt.headers, header, got, want)
+			}
+		}
+	}
+}
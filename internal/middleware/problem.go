@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/VTerenya/employees/internal/requestctx"
+)
+
+const problemContentType = "application/problem+json"
+
+type Problem struct {
+	Type          string `json:"type"`
+	Title         string `json:"title"`
+	Status        int    `json:"status"`
+	Detail        string `json:"detail,omitempty"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
+
+func WriteProblem(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	p := Problem{
+		Type:          "about:blank",
+		Title:         http.StatusText(status),
+		Status:        status,
+		Detail:        detail,
+		CorrelationID: requestctx.CorrelationID(r.Context()),
+	}
+	jsonBytes, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", problemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_, er := w.Write(jsonBytes)
+	if er != nil {
+		requestctx.Logger(r.Context()).WithError(er).Warn("write problem response")
+	}
+}
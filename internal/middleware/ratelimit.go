@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/ratelimit"
+	"github.com/VTerenya/employees/internal/requestctx"
+	"github.com/gorilla/mux"
+)
+
+func rateLimitClient(r *http.Request) string {
+	credential := apiKeyFromRequest(r)
+	if credential == "" {
+		credential = r.Header.Get("Authorization")
+	}
+	return ratelimit.ClientKey(auth.Subject(r.Context()), credential, r.RemoteAddr)
+}
+
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if tpl, err := route.GetPathTemplate(); err == nil {
+			return tpl
+		}
+	}
+	return r.URL.Path
+}
+
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+func NewRateLimitMiddleware(limiter ratelimit.Limiter, rules ratelimit.Rules) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limit, bucket := rules.For(r.Method, routeTemplate(r))
+			if !limit.Valid() {
+				next.ServeHTTP(w, r)
+				return
+			}
+			res, err := limiter.Allow(r.Context(), rateLimitClient(r)+"|"+bucket, limit)
+			if err != nil {
+				requestctx.Logger(r.Context()).WithError(err).Error("rate limiter unavailable")
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			w.Header().Set("RateLimit-Reset", seconds(res.Reset))
+			if !res.Allowed {
+				w.Header().Set("Retry-After", seconds(res.RetryAfter))
+				WriteProblem(w, r, http.StatusTooManyRequests, "rate limit exceeded")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
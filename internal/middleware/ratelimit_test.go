@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VTerenya/employees/internal/ratelimit"
+	"github.com/gorilla/mux"
+)
+
+func newRateLimitedRouter(rules ratelimit.Rules) http.Handler {
+	r := mux.NewRouter()
+	r.HandleFunc("/employee/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	r.Use(NewRateLimitMiddleware(ratelimit.NewMemoryLimiter(), rules))
+	return r
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	h := newRateLimitedRouter(ratelimit.Rules{Default: ratelimit.Limit{RequestsPerSecond: 0.5, Burst: 2}})
+	send := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+	for i, remaining := range []string{"1", "0"} {
+		w := send("/employee/" + string(rune('a'+i)))
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("request %d: code %d", i, w.Code)
+		}
+		if w.Header().Get("RateLimit-Limit") != "2" || w.Header().Get("RateLimit-Remaining") != remaining {
+			t.Fatalf("request %d: headers %v", i, w.Header())
+		}
+		if w.Header().Get("Retry-After") != "" {
+			t.Fatalf("request %d: Retry-After on an allowed request", i)
+		}
+	}
+	w := send("/employee/c")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("over the limit: code %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") != "2" || w.Header().Get("RateLimit-Reset") != "4" ||
+		w.Header().Get("RateLimit-Remaining") != "0" {
+		t.Fatalf("over the limit: headers %v", w.Header())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Fatalf("content type %q", ct)
+	}
+}
+
+func TestRateLimitMiddlewareKeysByCredential(t *testing.T) {
+	h := newRateLimitedRouter(ratelimit.Rules{Default: ratelimit.Limit{RequestsPerSecond: 1, Burst: 1}})
+	send := func(header, value string) int {
+		req := httptest.NewRequest(http.MethodGet, "/employee/1", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w.Code
+	}
+	for _, tt := range []struct {
+		header, value string
+	}{
+		{"", ""},
+		{APIKeyHeader, "key-one"},
+		{APIKeyHeader, "key-two"},
+		{"Authorization", "Bearer token"},
+	} {
+		if code := send(tt.header, tt.value); code != http.StatusNoContent {
+			t.Fatalf("first request with %s %q: code %d", tt.header, tt.value, code)
+		}
+		if code := send(tt.header, tt.value); code != http.StatusTooManyRequests {
+			t.Fatalf("second request with %s %q: code %d", tt.header, tt.value, code)
+		}
+	}
+}
+
+func TestRateLimitMiddlewareRouteRules(t *testing.T) {
+	h := newRateLimitedRouter(ratelimit.Rules{
+		Default: ratelimit.Limit{RequestsPerSecond: 1, Burst: 1},
+		Routes:  map[string]ratelimit.Limit{"GET /employee/{id}": {RequestsPerSecond: 1, Burst: 3}},
+	})
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/employee/1", nil))
+		if w.Code != http.StatusNoContent || w.Header().Get("RateLimit-Limit") != "3" {
+			t.Fatalf("request %d: code %d, headers %v", i, w.Code, w.Header())
+		}
+	}
+}
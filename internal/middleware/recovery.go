@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strings"
+
+	"github.com/VTerenya/employees/internal/requestctx"
+	"github.com/sirupsen/logrus"
+)
+
+var panicsTotal = expvar.NewInt("http_panics_total") // nolint: gochecknoglobals
+
+func LogPanic(ctx context.Context, rec interface{}) {
+	requestctx.Logger(ctx).WithFields(logrus.Fields{
+		"type":  "panic",
+		"panic": fmt.Sprint(rec),
+		"stack": strings.Split(strings.TrimSpace(string(debug.Stack())), "\n"),
+	}).Error("recovered from panic")
+}
+
+func RecoveryMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler { // nolint: errorlint
+				panic(rec)
+			}
+			panicsTotal.Add(1)
+			LogPanic(r.Context(), rec)
+			WriteProblem(w, r, http.StatusInternalServerError, "internal server error")
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecoveryMiddlewareWritesProblem(t *testing.T) {
+	h := IDMiddleware(RecoveryMiddleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	})))
+	before := panicsTotal.Value()
+	req := httptest.NewRequest(http.MethodGet, "/position/1", nil)
+	req.Header.Set(RequestIDHeader, "req-42")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != problemContentType {
+		t.Fatalf("content type = %q", ct)
+	}
+	var p Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+	want := Problem{
+		Type:          "about:blank",
+		Title:         "Internal Server Error",
+		Status:        http.StatusInternalServerError,
+		Detail:        "internal server error",
+		CorrelationID: "req-42",
+	}
+	if p != want {
+		t.Fatalf("problem = %+v, want %+v", p, want)
+	}
+	if got := rec.Header().Get(CorrelationIDHeader); got != "req-42" {
+		t.Fatalf("correlation header = %q", got)
+	}
+	if got := panicsTotal.Value() - before; got != 1 {
+		t.Fatalf("http_panics_total grew by %d, want 1", got)
+	}
+}
+
+func TestRecoveryMiddlewareRepanicsOnAbort(t *testing.T) {
+	h := RecoveryMiddleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	before := panicsTotal.Value()
+	defer func() {
+		if rec := recover(); rec != http.ErrAbortHandler { // nolint: errorlint
+			t.Fatalf("recovered %v, want http.ErrAbortHandler", rec)
+		}
+		if panicsTotal.Value() != before {
+			t.Fatal("aborted request counted as a panic")
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
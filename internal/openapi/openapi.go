@@ -0,0 +1,58 @@
+package openapi
+
+import (
+	"embed"
+	"net/http"
+	"strings"
+
+	swaggerFiles "github.com/swaggo/files"
+)
+
+//go:embed openapi.json
+var spec []byte
+
+//go:embed ui
+var ui embed.FS
+
+const docsCSP = "default-src 'self'; img-src 'self' data:; style-src 'self' 'unsafe-inline'; frame-ancestors 'none'"
+
+func Spec() []byte {
+	return spec
+}
+
+func Handler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, er := w.Write(spec)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
+
+func DocsHandler(prefix string) http.Handler {
+	assets := http.StripPrefix(prefix, http.FileServer(swaggerFiles.HTTP))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Security-Policy", docsCSP)
+		name := strings.TrimPrefix(r.URL.Path, prefix)
+		switch name {
+		case "", "index.html":
+			serveUI(w, "ui/index.html", "text/html; charset=utf-8")
+		case "init.js":
+			serveUI(w, "ui/init.js", "application/javascript")
+		default:
+			assets.ServeHTTP(w, r)
+		}
+	})
+}
+
+func serveUI(w http.ResponseWriter, name, contentType string) {
+	data, err := ui.ReadFile(name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	_, er := w.Write(data)
+	if er != nil {
+		http.Error(w, er.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,141 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var plainScalar = regexp.MustCompile(`^[A-Za-z/_$][A-Za-z0-9 _./{}$+-]*$`) // nolint: gochecknoglobals
+
+// node keeps the members of a JSON object in document order.
+type node struct {
+	keys   []string
+	values []*node
+	items  []*node
+	scalar interface{}
+	kind   json.Delim
+}
+
+// YAML renders the embedded document as YAML for tools that do not read the
+// served JSON. It is the source of .openapi.yaml at the repository root.
+func YAML() ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(spec))
+	dec.UseNumber()
+	root, err := decodeNode(dec)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteString("# Generated from internal/openapi/openapi.json by `make openapi`. Do not edit.\n")
+	writeYAML(&buf, root, 0)
+	return buf.Bytes(), nil
+}
+
+func decodeNode(dec *json.Decoder) (*node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return &node{scalar: tok}, nil
+	}
+	n := &node{kind: delim}
+	for dec.More() {
+		if delim == '{' {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			n.keys = append(n.keys, key.(string))
+		}
+		child, err := decodeNode(dec)
+		if err != nil {
+			return nil, err
+		}
+		if delim == '{' {
+			n.values = append(n.values, child)
+		} else {
+			n.items = append(n.items, child)
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *node) empty() bool {
+	return n.kind != 0 && len(n.keys) == 0 && len(n.items) == 0
+}
+
+func writeYAML(w io.Writer, n *node, indent int) {
+	pad := strings.Repeat("  ", indent)
+	switch n.kind {
+	case '{':
+		for i, key := range n.keys {
+			fmt.Fprintf(w, "%s%s:", pad, quote(key))
+			writeValue(w, n.values[i], indent+1)
+		}
+	case '[':
+		for _, item := range n.items {
+			fmt.Fprintf(w, "%s-", pad)
+			if item.kind == '{' && !item.empty() {
+				// The first member shares the line with the dash.
+				var buf bytes.Buffer
+				writeYAML(&buf, item, indent+1)
+				fmt.Fprintf(w, " %s", strings.TrimPrefix(buf.String(), pad+"  "))
+				continue
+			}
+			writeValue(w, item, indent+1)
+		}
+	}
+}
+
+func writeValue(w io.Writer, n *node, indent int) {
+	switch {
+	case n.kind == '{' && n.empty():
+		fmt.Fprintln(w, " {}")
+	case n.kind == '[' && n.empty():
+		fmt.Fprintln(w, " []")
+	case n.kind != 0:
+		fmt.Fprintln(w)
+		writeYAML(w, n, indent)
+	default:
+		fmt.Fprintf(w, " %s\n", scalar(n.scalar))
+	}
+}
+
+func scalar(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return quote(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// quote leaves strings plain only when YAML cannot read them as anything
+// else.
+func quote(s string) string {
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "y", "n", "null", "~":
+	default:
+		if plainScalar.MatchString(s) && !strings.HasSuffix(s, " ") && !strings.Contains(s, " #") {
+			return s
+		}
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return fmt.Sprintf("%q", s)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,31 @@
+package openapi
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite .openapi.yaml from openapi.json") // nolint: gochecknoglobals
+
+const yamlFile = "../../.openapi.yaml"
+
+func TestYAMLMatchesJSON(t *testing.T) {
+	want, err := YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		if err := os.WriteFile(yamlFile, want, 0o644); err != nil { // nolint: gosec
+			t.Fatal(err)
+		}
+	}
+	got, err := os.ReadFile(yamlFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal(".openapi.yaml is out of date with openapi.json; run make openapi")
+	}
+}
@@ -0,0 +1,18 @@
+package internal
+
+import "github.com/google/uuid"
+
+type OrgChartNode struct {
+	ID         uuid.UUID       `json:"id"`
+	FirstName  string          `json:"first_name"`
+	LastName   string          `json:"last_name"`
+	PositionID uuid.UUID       `json:"position_id"`
+	Title      string          `json:"title,omitempty"`
+	Reports    []*OrgChartNode `json:"reports,omitempty"`
+}
+
+type OrgChartQuery struct {
+	EmployeeID   string
+	DepartmentID string
+	Depth        int
+}
@@ -0,0 +1,90 @@
+package orgchart
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/VTerenya/employees/internal"
+)
+
+const (
+	FormatJSON    = "json"
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+)
+
+func ValidFormat(format string) bool {
+	switch format {
+	case FormatJSON, FormatDOT, FormatMermaid:
+		return true
+	}
+	return false
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatDOT:
+		return "text/vnd.graphviz; charset=utf-8"
+	case FormatMermaid:
+		return "text/plain; charset=utf-8"
+	}
+	return "application/json"
+}
+
+func label(n *internal.OrgChartNode) (string, string) {
+	return strings.TrimSpace(n.FirstName + " " + n.LastName), n.Title
+}
+
+func nodeID(n *internal.OrgChartNode) string {
+	return "e" + strings.ReplaceAll(n.ID.String(), "-", "")
+}
+
+func walk(nodes []*internal.OrgChartNode, parent *internal.OrgChartNode, visit func(n, parent *internal.OrgChartNode)) {
+	for _, n := range nodes {
+		visit(n, parent)
+		walk(n.Reports, n, visit)
+	}
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ") // nolint: gochecknoglobals
+
+func WriteDOT(w io.Writer, nodes []*internal.OrgChartNode) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "digraph orgchart {")
+	fmt.Fprintln(b, "  rankdir=TB;")
+	fmt.Fprintln(b, "  node [shape=box, style=rounded];")
+	walk(nodes, nil, func(n, parent *internal.OrgChartNode) {
+		name, title := label(n)
+		text := dotEscaper.Replace(name)
+		if title != "" {
+			text += `\n` + dotEscaper.Replace(title)
+		}
+		fmt.Fprintf(b, "  %s [label=\"%s\"];\n", nodeID(n), text)
+		if parent != nil {
+			fmt.Fprintf(b, "  %s -> %s;\n", nodeID(parent), nodeID(n))
+		}
+	})
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
+
+var mermaidEscaper = strings.NewReplacer(`"`, "#quot;", "\n", " ", "<", "#lt;", ">", "#gt;") // nolint: gochecknoglobals
+
+func WriteMermaid(w io.Writer, nodes []*internal.OrgChartNode) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "flowchart TD")
+	walk(nodes, nil, func(n, parent *internal.OrgChartNode) {
+		name, title := label(n)
+		text := mermaidEscaper.Replace(name)
+		if title != "" {
+			text += "<br/>" + mermaidEscaper.Replace(title)
+		}
+		fmt.Fprintf(b, "  %s[\"%s\"]\n", nodeID(n), text)
+		if parent != nil {
+			fmt.Fprintf(b, "  %s --> %s\n", nodeID(parent), nodeID(n))
+		}
+	})
+	return b.Flush()
+}
@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type PayGrade struct {
+	ID       uuid.UUID       `json:"id"`
+	Name     string          `json:"name"`
+	Min      decimal.Decimal `json:"min"`
+	Mid      decimal.Decimal `json:"mid"`
+	Max      decimal.Decimal `json:"max"`
+	Currency string          `json:"currency"`
+}
+
+func (g PayGrade) InBand(salary decimal.Decimal) bool {
+	return !salary.LessThan(g.Min) && !salary.GreaterThan(g.Max)
+}
+
+type CompaRatio struct {
+	PositionID     uuid.UUID       `json:"position_id"`
+	PositionName   string          `json:"position_name"`
+	PayGradeID     uuid.UUID       `json:"pay_grade_id"`
+	PayGradeName   string          `json:"pay_grade_name"`
+	Salary         decimal.Decimal `json:"salary"`
+	Min            decimal.Decimal `json:"min"`
+	Mid            decimal.Decimal `json:"mid"`
+	Max            decimal.Decimal `json:"max"`
+	Currency       string          `json:"currency"`
+	CompaRatio     decimal.Decimal `json:"compa_ratio"`
+	InBand         bool            `json:"in_band"`
+	OverrideReason string          `json:"override_reason,omitempty"`
+}
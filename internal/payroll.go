@@ -0,0 +1,36 @@
+package internal
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type PayrollStats struct {
+	Headcount int             `json:"headcount"`
+	Total     decimal.Decimal `json:"total"`
+	Average   decimal.Decimal `json:"average"`
+	Median    decimal.Decimal `json:"median"`
+	Min       decimal.Decimal `json:"min"`
+	Max       decimal.Decimal `json:"max"`
+}
+
+type PositionPayroll struct {
+	PositionID   uuid.UUID  `json:"position_id"`
+	PositionName string     `json:"position_name"`
+	DepartmentID *uuid.UUID `json:"department_id,omitempty"`
+	PayrollStats
+}
+
+type DepartmentPayroll struct {
+	DepartmentID   *uuid.UUID `json:"department_id,omitempty"`
+	DepartmentName string     `json:"department_name,omitempty"`
+	PayrollStats
+}
+
+type PayrollReport struct {
+	Currency string `json:"currency"`
+	AsOf     Date   `json:"as_of"`
+	PayrollStats
+	Positions   []PositionPayroll   `json:"positions"`
+	Departments []DepartmentPayroll `json:"departments"`
+}
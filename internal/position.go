@@ -6,7 +6,22 @@ import (
 )
 
 type Position struct {
-	ID     uuid.UUID       `json:"id"`
-	Name   string          `json:"name"`
-	Salary decimal.Decimal `json:"salary"`
+	ID                   uuid.UUID       `json:"id"`
+	Name                 string          `json:"name"`
+	Salary               decimal.Decimal `json:"salary"`
+	Currency             string          `json:"currency"`
+	DepartmentID         *uuid.UUID      `json:"department_id,omitempty"`
+	PayGradeID           *uuid.UUID      `json:"pay_grade_id,omitempty"`
+	SalaryOverrideReason string          `json:"salary_override_reason,omitempty"`
+	Attributes           Attributes      `json:"attributes,omitempty"`
+}
+
+// Redacted is the position as shown to callers without salary:read. The pay
+// grade goes with the salary because its band reveals the pay range.
+func (p Position) Redacted() Position {
+	p.Salary = decimal.Zero
+	p.Currency = ""
+	p.PayGradeID = nil
+	p.SalaryOverrideReason = ""
+	return p
 }
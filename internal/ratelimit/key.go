@@ -0,0 +1,27 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+)
+
+// ClientKey names the bucket a caller draws from before authentication has
+// run: an authenticated subject if one is already known, then a digest of the
+// presented API key or bearer token, then the client IP. The digest keeps
+// secrets out of the limiter while still giving each credential its own
+// budget behind a shared address.
+func ClientKey(subject, credential, remoteAddr string) string {
+	if subject != "" {
+		return "sub:" + subject
+	}
+	if credential != "" {
+		sum := sha256.Sum256([]byte(credential))
+		return "cred:" + hex.EncodeToString(sum[:16])
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return "ip:" + remoteAddr
+	}
+	return "ip:" + host
+}
@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryLimiterRefillsTokens(t *testing.T) {
+	now := time.Unix(1000, 0)
+	m := NewMemoryLimiter()
+	m.now = func() time.Time { return now }
+	limit := Limit{RequestsPerSecond: 2, Burst: 3}
+	allow := func() Result {
+		t.Helper()
+		res, err := m.Allow(context.Background(), "client", limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+	for i := 2; i >= 0; i-- {
+		res := allow()
+		if !res.Allowed || res.Remaining != i || res.Limit != 3 {
+			t.Fatalf("burst request: %+v, want allowed with %d remaining", res, i)
+		}
+	}
+	res := allow()
+	if res.Allowed {
+		t.Fatal("request over the burst allowed")
+	}
+	if res.RetryAfter != 500*time.Millisecond || res.Reset != 1500*time.Millisecond {
+		t.Fatalf("retry after %v, reset %v", res.RetryAfter, res.Reset)
+	}
+	now = now.Add(500 * time.Millisecond)
+	if res := allow(); !res.Allowed || res.Remaining != 0 {
+		t.Fatalf("after one refill interval: %+v", res)
+	}
+	now = now.Add(time.Hour)
+	if res := allow(); !res.Allowed || res.Remaining != 2 {
+		t.Fatalf("refill must stop at the burst: %+v", res)
+	}
+}
+
+func TestMemoryLimiterSeparatesKeys(t *testing.T) {
+	m := NewMemoryLimiter()
+	limit := Limit{RequestsPerSecond: 1, Burst: 1}
+	for _, key := range []string{"a", "b"} {
+		res, err := m.Allow(context.Background(), key, limit)
+		if err != nil || !res.Allowed {
+			t.Fatalf("%s: %+v, %v", key, res, err)
+		}
+	}
+	if res, _ := m.Allow(context.Background(), "a", limit); res.Allowed {
+		t.Fatal("second request for a allowed")
+	}
+}
+
+func TestMemoryLimiterSweepsIdleBuckets(t *testing.T) {
+	now := time.Unix(1000, 0)
+	m := NewMemoryLimiter()
+	m.now = func() time.Time { return now }
+	limit := Limit{RequestsPerSecond: 1, Burst: 1}
+	if _, err := m.Allow(context.Background(), "idle", limit); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(2 * defaultIdle)
+	if _, err := m.Allow(context.Background(), "fresh", limit); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.buckets["idle"]; ok {
+		t.Fatal("idle bucket kept")
+	}
+}
@@ -0,0 +1,37 @@
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Rules struct {
+	Default Limit            `json:"default"`
+	Routes  map[string]Limit `json:"routes"`
+}
+
+func (r Rules) For(method, route string) (Limit, string) {
+	key := method + " " + route
+	if l, ok := r.Routes[key]; ok {
+		return l, key
+	}
+	return r.Default, "default"
+}
+
+func (l Limit) Valid() bool {
+	return l.RequestsPerSecond > 0 && l.Burst > 0
+}
+
+// Validate rejects limits that would let every request through, such as a
+// zero burst, instead of silently disabling them.
+func (r Rules) Validate() error {
+	if !r.Default.Valid() {
+		return errors.New("rate limit default: requests_per_second and burst must be positive")
+	}
+	for route, l := range r.Routes {
+		if !l.Valid() {
+			return fmt.Errorf("rate limit %q: requests_per_second and burst must be positive", route)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package ratelimit
+
+import "testing"
+
+func TestRulesFor(t *testing.T) {
+	rules := Rules{
+		Default: Limit{RequestsPerSecond: 10, Burst: 20},
+		Routes:  map[string]Limit{"POST /employee": {RequestsPerSecond: 1, Burst: 2}},
+	}
+	if l, bucket := rules.For("POST", "/employee"); l.Burst != 2 || bucket != "POST /employee" {
+		t.Fatalf("route rule: %+v in %q", l, bucket)
+	}
+	if l, bucket := rules.For("GET", "/employee"); l.Burst != 20 || bucket != "default" {
+		t.Fatalf("default rule: %+v in %q", l, bucket)
+	}
+}
+
+func TestRulesValidate(t *testing.T) {
+	valid := Limit{RequestsPerSecond: 1, Burst: 1}
+	for _, tt := range []struct {
+		name  string
+		rules Rules
+		ok    bool
+	}{
+		{"valid", Rules{Default: valid, Routes: map[string]Limit{"GET /employees": valid}}, true},
+		{"zero default burst", Rules{Default: Limit{RequestsPerSecond: 1}}, false},
+		{"zero default rate", Rules{Default: Limit{Burst: 1}}, false},
+		{"negative route rate", Rules{
+			Default: valid,
+			Routes:  map[string]Limit{"GET /employees": {RequestsPerSecond: -1, Burst: 1}},
+		}, false},
+		{"zero route burst", Rules{
+			Default: valid,
+			Routes:  map[string]Limit{"GET /employees": {RequestsPerSecond: 1}},
+		}, false},
+	} {
+		if err := tt.rules.Validate(); (err == nil) != tt.ok {
+			t.Errorf("%s: Validate() = %v", tt.name, err)
+		}
+	}
+}
+
+func TestClientKey(t *testing.T) {
+	byIP := ClientKey("", "", "10.0.0.1:5000")
+	if byIP != "ip:10.0.0.1" || ClientKey("", "", "10.0.0.1:6000") != byIP {
+		t.Fatalf("ip key = %q", byIP)
+	}
+	first := ClientKey("", "Bearer one", "10.0.0.1:5000")
+	second := ClientKey("", "Bearer two", "10.0.0.1:5000")
+	if first == second || first == byIP {
+		t.Fatalf("credentials behind one address share a bucket: %q, %q", first, second)
+	}
+	if first != ClientKey("", "Bearer one", "10.0.0.2:5000") {
+		t.Fatal("credential key depends on the address")
+	}
+	if ClientKey("payroll", "Bearer one", "10.0.0.1:5000") != "sub:payroll" {
+		t.Fatal("subject must win over the credential")
+	}
+}
@@ -0,0 +1,306 @@
+package report
+
+import (
+	"sort"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	turnoverPlaces = 4
+	averagePlaces  = 2
+	hoursPerDay    = 24
+)
+
+// Span is a stretch of employment in one position. An employee counts on
+// every day from From up to, but not including, To; a zero From means the
+// employment started before any recorded history and a zero To that it is
+// still ongoing.
+type Span struct {
+	EmployeeID  uuid.UUID
+	PositionID  uuid.UUID
+	From        internal.Date
+	To          internal.Date
+	Hired       bool
+	Terminated  bool
+	TenureStart internal.Date
+}
+
+func (s Span) activeOn(d internal.Date) bool {
+	return (s.From.IsZero() || !s.From.After(d.Time)) && (s.To.IsZero() || d.Before(s.To.Time))
+}
+
+func (s Span) hiredIn(from, to internal.Date) bool {
+	return s.Hired && within(s.From, from, to)
+}
+
+func (s Span) terminatedIn(from, to internal.Date) bool {
+	return s.Terminated && within(s.To, from, to)
+}
+
+func within(d, from, to internal.Date) bool {
+	return !d.Before(from.Time) && !d.After(to.Time)
+}
+
+// BuildSpans replays the lifecycle events of every employee in the log and
+// of every employee record, current or deleted, given in records. The
+// records only fill in what predates the log: employees without a hire
+// event are taken to have been employed since their hire date, when known,
+// in the position they held before their first transfer.
+func BuildSpans(records map[string]internal.Employee, events []internal.LifecycleEvent) []Span {
+	byEmployee := make(map[uuid.UUID][]internal.LifecycleEvent)
+	for _, e := range events {
+		byEmployee[e.EmployeeID] = append(byEmployee[e.EmployeeID], e)
+	}
+	for _, e := range records {
+		if _, ok := byEmployee[e.ID]; !ok {
+			byEmployee[e.ID] = nil
+		}
+	}
+	spans := make([]Span, 0, len(byEmployee))
+	for id, history := range byEmployee {
+		e, ok := records[id.String()]
+		if !ok {
+			e = internal.Employee{ID: id}
+		}
+		sort.SliceStable(history, func(i, j int) bool {
+			if !history[i].EffectiveDate.Equal(history[j].EffectiveDate.Time) {
+				return history[i].EffectiveDate.Before(history[j].EffectiveDate.Time)
+			}
+			return history[i].RecordedAt.Before(history[j].RecordedAt)
+		})
+		spans = append(spans, employeeSpans(e, history)...)
+	}
+	return spans
+}
+
+func employeeSpans(e internal.Employee, history []internal.LifecycleEvent) []Span {
+	spans := make([]Span, 0, len(history)+1)
+	position := e.PositionID
+	for _, event := range history {
+		if event.FromPositionID != nil {
+			position = *event.FromPositionID
+			break
+		}
+	}
+	var current *Span
+	if len(history) == 0 || history[0].Type != internal.EventHire {
+		current = &Span{EmployeeID: e.ID, PositionID: position}
+		if e.HireDate != nil {
+			current.From, current.Hired, current.TenureStart = *e.HireDate, true, *e.HireDate
+		}
+	}
+	for _, event := range history {
+		if event.ToPositionID != nil {
+			position = *event.ToPositionID
+		}
+		switch {
+		case event.Type == internal.EventHire || event.Type == internal.EventRehire:
+			if current == nil {
+				current = &Span{
+					EmployeeID:  e.ID,
+					PositionID:  position,
+					From:        event.EffectiveDate,
+					Hired:       true,
+					TenureStart: event.EffectiveDate,
+				}
+			}
+		case current == nil:
+		case event.Type == internal.EventTerminate:
+			current.To, current.Terminated = event.EffectiveDate, true
+			spans = append(spans, *current)
+			current = nil
+		case position != current.PositionID:
+			current.To = event.EffectiveDate
+			spans = append(spans, *current)
+			current = &Span{
+				EmployeeID:  e.ID,
+				PositionID:  position,
+				From:        event.EffectiveDate,
+				TenureStart: current.TenureStart,
+			}
+		}
+	}
+	if current != nil {
+		spans = append(spans, *current)
+	}
+	return spans
+}
+
+// Periods splits the range into calendar intervals clipped to From and To.
+func Periods(q internal.ReportRange) []internal.HeadcountPeriod {
+	answer := make([]internal.HeadcountPeriod, 0)
+	start := intervalStart(q.From, q.Interval)
+	for !start.After(q.To.Time) {
+		next := internal.NewDate(start.AddDate(0, intervalMonths(q.Interval), 0))
+		period := internal.HeadcountPeriod{
+			Start: start,
+			End:   internal.NewDate(next.AddDate(0, 0, -1)),
+		}
+		if period.Start.Before(q.From.Time) {
+			period.Start = q.From
+		}
+		if period.End.After(q.To.Time) {
+			period.End = q.To
+		}
+		answer = append(answer, period)
+		start = next
+	}
+	return answer
+}
+
+func intervalStart(d internal.Date, interval string) internal.Date {
+	month := d.Month()
+	switch interval {
+	case internal.IntervalQuarter:
+		month -= (month - 1) % 3
+	case internal.IntervalYear:
+		month = 1
+	}
+	return internal.NewDate(d.AddDate(0, int(month-d.Month()), 1-d.Day()))
+}
+
+func intervalMonths(interval string) int {
+	switch interval {
+	case internal.IntervalQuarter:
+		return 3
+	case internal.IntervalYear:
+		return 12
+	}
+	return 1
+}
+
+func Headcount(
+	spans []Span, positions map[string]internal.Position, q internal.ReportRange,
+) internal.HeadcountReport {
+	report := internal.HeadcountReport{From: q.From, To: q.To, Interval: q.Interval, Periods: Periods(q)}
+	for i := range report.Periods {
+		period := &report.Periods[i]
+		byPosition := make(map[uuid.UUID]*internal.PositionHeadcount)
+		group := func(id uuid.UUID) *internal.PositionHeadcount {
+			if _, ok := byPosition[id]; !ok {
+				byPosition[id] = &internal.PositionHeadcount{PositionID: id, PositionName: positions[id.String()].Name}
+			}
+			return byPosition[id]
+		}
+		for _, s := range spans {
+			if s.activeOn(period.End) {
+				period.Headcount++
+				group(s.PositionID).Headcount++
+			}
+			if s.hiredIn(period.Start, period.End) {
+				period.Hires++
+				group(s.PositionID).Hires++
+			}
+			if s.terminatedIn(period.Start, period.End) {
+				period.Terminations++
+				group(s.PositionID).Terminations++
+			}
+		}
+		period.Positions = make([]internal.PositionHeadcount, 0, len(byPosition))
+		for _, p := range byPosition {
+			period.Positions = append(period.Positions, *p)
+		}
+		sort.Slice(period.Positions, func(i, j int) bool {
+			a, b := period.Positions[i], period.Positions[j]
+			if a.PositionName != b.PositionName {
+				return a.PositionName < b.PositionName
+			}
+			return a.PositionID.String() < b.PositionID.String()
+		})
+	}
+	return report
+}
+
+type turnoverCounter struct {
+	internal.TurnoverStats
+	sampled     int64
+	tenureDays  int64
+	tenureCount int64
+}
+
+func (c *turnoverCounter) add(s Span, from, to internal.Date, samples []internal.Date) {
+	if s.activeOn(from) {
+		c.HeadcountStart++
+	}
+	for _, d := range samples {
+		if s.activeOn(d) {
+			c.sampled++
+		}
+	}
+	if s.activeOn(to) {
+		c.HeadcountEnd++
+		if !s.TenureStart.IsZero() {
+			c.tenureDays += int64(to.Sub(s.TenureStart.Time).Hours()) / hoursPerDay
+			c.tenureCount++
+		}
+	}
+	if s.hiredIn(from, to) {
+		c.Hires++
+	}
+	if s.terminatedIn(from, to) {
+		c.Terminations++
+	}
+}
+
+// stats derives the turnover rate as terminations over the headcount
+// averaged across the samples, and the mean company tenure of the employees
+// counted at the end of the range.
+func (c *turnoverCounter) stats(samples int) internal.TurnoverStats {
+	s := c.TurnoverStats
+	s.AverageHeadcount = decimal.NewFromInt(c.sampled).DivRound(decimal.NewFromInt(int64(samples)), averagePlaces)
+	if c.sampled > 0 {
+		terminations := decimal.NewFromInt(int64(s.Terminations) * int64(samples))
+		s.TurnoverRate = terminations.DivRound(decimal.NewFromInt(c.sampled), turnoverPlaces)
+	}
+	if c.tenureCount > 0 {
+		s.AverageTenureDays = decimal.NewFromInt(c.tenureDays).DivRound(decimal.NewFromInt(c.tenureCount), averagePlaces)
+	}
+	return s
+}
+
+// Turnover samples the headcount on From and on the last day of every
+// interval in the range.
+func Turnover(spans []Span, positions map[string]internal.Position, q internal.ReportRange) internal.TurnoverReport {
+	from, to := q.From, q.To
+	samples := []internal.Date{from}
+	for _, p := range Periods(q) {
+		samples = append(samples, p.End)
+	}
+	total := &turnoverCounter{}
+	byPosition := make(map[uuid.UUID]*turnoverCounter)
+	for _, s := range spans {
+		if !s.activeOn(from) && !s.activeOn(to) && !s.hiredIn(from, to) && !s.terminatedIn(from, to) {
+			continue
+		}
+		total.add(s, from, to, samples)
+		if _, ok := byPosition[s.PositionID]; !ok {
+			byPosition[s.PositionID] = &turnoverCounter{}
+		}
+		byPosition[s.PositionID].add(s, from, to, samples)
+	}
+	report := internal.TurnoverReport{
+		From:          from,
+		To:            to,
+		Interval:      q.Interval,
+		TurnoverStats: total.stats(len(samples)),
+		Positions:     make([]internal.PositionTurnover, 0, len(byPosition)),
+	}
+	for id, c := range byPosition {
+		report.Positions = append(report.Positions, internal.PositionTurnover{
+			PositionID:    id,
+			PositionName:  positions[id.String()].Name,
+			TurnoverStats: c.stats(len(samples)),
+		})
+	}
+	sort.Slice(report.Positions, func(i, j int) bool {
+		a, b := report.Positions[i], report.Positions[j]
+		if a.PositionName != b.PositionName {
+			return a.PositionName < b.PositionName
+		}
+		return a.PositionID.String() < b.PositionID.String()
+	})
+	return report
+}
@@ -0,0 +1,41 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/google/uuid"
+)
+
+func date(t *testing.T, s string) internal.Date {
+	t.Helper()
+	d, err := internal.ParseDate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestBuildSpansFromEventsOnly(t *testing.T) {
+	employee, dev, lead := uuid.New(), uuid.New(), uuid.New()
+	events := []internal.LifecycleEvent{
+		{EmployeeID: employee, Type: internal.EventTerminate, EffectiveDate: date(t, "2024-06-01")},
+		{EmployeeID: employee, Type: internal.EventHire, EffectiveDate: date(t, "2024-01-01"), ToPositionID: &dev},
+		{
+			EmployeeID: employee, Type: internal.EventTransfer, EffectiveDate: date(t, "2024-03-01"),
+			FromPositionID: &dev, ToPositionID: &lead,
+		},
+	}
+	spans := BuildSpans(nil, events)
+	if len(spans) != 2 {
+		t.Fatalf("spans = %+v", spans)
+	}
+	first, second := spans[0], spans[1]
+	if first.PositionID != dev || !first.Hired || first.From != date(t, "2024-01-01") || first.To != date(t, "2024-03-01") {
+		t.Fatalf("first span = %+v", first)
+	}
+	if second.PositionID != lead || !second.Terminated || second.To != date(t, "2024-06-01") ||
+		second.TenureStart != date(t, "2024-01-01") {
+		t.Fatalf("second span = %+v", second)
+	}
+}
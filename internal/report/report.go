@@ -0,0 +1,82 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/google/uuid"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+func ValidFormat(format string) bool {
+	switch format {
+	case FormatJSON, FormatCSV:
+		return true
+	}
+	return false
+}
+
+func ContentType(format string) string {
+	if format == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/json"
+}
+
+const (
+	groupPosition   = "position"
+	groupDepartment = "department"
+	groupTotal      = "total"
+)
+
+var payrollHeader = []string{ // nolint: gochecknoglobals
+	"group", "id", "name", "department_id", "headcount", "total", "average", "median", "min", "max", "currency",
+}
+
+func WritePayrollCSV(w io.Writer, r internal.PayrollReport) error {
+	c := csv.NewWriter(w)
+	rows := [][]string{payrollHeader}
+	for _, p := range r.Positions {
+		rows = append(rows, payrollRow(groupPosition, p.PositionID.String(), p.PositionName,
+			optionalID(p.DepartmentID), p.PayrollStats, r.Currency))
+	}
+	for _, d := range r.Departments {
+		rows = append(rows, payrollRow(groupDepartment, optionalID(d.DepartmentID), d.DepartmentName,
+			optionalID(d.DepartmentID), d.PayrollStats, r.Currency))
+	}
+	rows = append(rows, payrollRow(groupTotal, "", "", "", r.PayrollStats, r.Currency))
+	err := c.WriteAll(rows)
+	if err != nil {
+		return err
+	}
+	return c.Error()
+}
+
+func payrollRow(group, id, name, department string, s internal.PayrollStats, currency string) []string {
+	return []string{
+		group, id, cell(name), department, strconv.Itoa(s.Headcount),
+		s.Total.String(), s.Average.String(), s.Median.String(), s.Min.String(), s.Max.String(), currency,
+	}
+}
+
+// cell keeps spreadsheets from evaluating user-entered text as a formula.
+func cell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func optionalID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
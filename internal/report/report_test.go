@@ -0,0 +1,43 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/google/uuid"
+)
+
+func TestWritePayrollCSVEscapesFormulas(t *testing.T) {
+	names := map[string]string{
+		"=HYPERLINK(\"x\")": "'=HYPERLINK(\"x\")",
+		"+1":                "'+1",
+		"-1":                "'-1",
+		"@SUM(A1)":          "'@SUM(A1)",
+		"\tdev":             "'\tdev",
+		"Engineer":          "Engineer",
+		"":                  "",
+	}
+	var r internal.PayrollReport
+	for name := range names {
+		r.Positions = append(r.Positions, internal.PositionPayroll{PositionID: uuid.New(), PositionName: name})
+	}
+	var buf bytes.Buffer
+	if err := WritePayrollCSV(&buf, r); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]bool)
+	for _, row := range rows[1 : len(rows)-1] {
+		got[row[2]] = true
+	}
+	for name, want := range names {
+		if !got[want] {
+			t.Errorf("name %q: no row with %q", name, want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+)
+
+func (t Repository) GetAPIKeys() []internal.APIKey {
+	t.data.mu.RLock()
+	defer t.data.mu.RUnlock()
+	keys := make([]internal.APIKey, 0, len(t.data.apiKeys))
+	for _, k := range t.data.apiKeys {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (t Repository) GetAPIKey(id string) (internal.APIKey, error) {
+	t.data.mu.RLock()
+	defer t.data.mu.RUnlock()
+	if k, ok := t.data.apiKeys[id]; ok {
+		return k, nil
+	}
+	return internal.APIKey{}, errors.NotFound()
+}
+
+func (t Repository) FindAPIKeyByHash(hash string) (internal.APIKey, error) {
+	t.data.mu.RLock()
+	defer t.data.mu.RUnlock()
+	for _, k := range t.data.apiKeys {
+		if k.Hash == hash {
+			return k, nil
+		}
+	}
+	return internal.APIKey{}, errors.NotFound()
+}
+
+func (t Repository) AddAPIKey(k *internal.APIKey) {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	t.data.apiKeys[k.ID.String()] = *k
+}
+
+func (t Repository) UpdateAPIKey(k *internal.APIKey) error {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	if _, ok := t.data.apiKeys[k.ID.String()]; ok {
+		t.data.apiKeys[k.ID.String()] = *k
+		return nil
+	}
+	return errors.NotFound()
+}
+
+func (t Repository) TouchAPIKey(id string, at time.Time) error {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	if k, ok := t.data.apiKeys[id]; ok {
+		k.LastUsedAt = &at
+		t.data.apiKeys[id] = k
+		return nil
+	}
+	return errors.NotFound()
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+)
+
+func (t Repository) GetAttributeDefinitions() []internal.AttributeDefinition {
+	t.data.mu.RLock()
+	defer t.data.mu.RUnlock()
+	answer := make([]internal.AttributeDefinition, 0, len(t.data.attributes))
+	for _, d := range t.data.attributes {
+		answer = append(answer, d)
+	}
+	return answer
+}
+
+func (t Repository) AddAttributeDefinition(d *internal.AttributeDefinition) {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	t.data.attributes[d.ID.String()] = *d
+}
+
+func (t Repository) UpdateAttributeDefinition(d *internal.AttributeDefinition) error {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	if _, ok := t.data.attributes[d.ID.String()]; ok {
+		t.data.attributes[d.ID.String()] = *d
+		return nil
+	}
+	return errors.NotFound()
+}
+
+// DeleteAttributeDefinition also strips the attribute from every record of
+// its entity under the same lock, so no update can slip a value in between.
+func (t Repository) DeleteAttributeDefinition(id string) error {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	d, ok := t.data.attributes[id]
+	if !ok {
+		return errors.NotFound()
+	}
+	delete(t.data.attributes, id)
+	switch d.Entity {
+	case internal.EntityEmployee:
+		for key, e := range t.data.employees {
+			if _, ok := e.Attributes[d.Name]; ok {
+				e.Attributes = withoutAttribute(e.Attributes, d.Name)
+				t.data.employees[key] = e
+				t.data.version++
+			}
+		}
+	case internal.EntityPosition:
+		for key, p := range t.data.positions {
+			if _, ok := p.Attributes[d.Name]; ok {
+				p.Attributes = withoutAttribute(p.Attributes, d.Name)
+				t.data.positions[key] = p
+			}
+		}
+	}
+	return nil
+}
+
+func withoutAttribute(attrs internal.Attributes, name string) internal.Attributes {
+	answer := make(internal.Attributes, len(attrs))
+	for k, v := range attrs {
+		if k != name {
+			answer[k] = v
+		}
+	}
+	if len(answer) == 0 {
+		return nil
+	}
+	return answer
+}
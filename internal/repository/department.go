@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+)
+
+func (t Repository) GetDepartments() map[string]internal.Department {
+	t.data.mu.RLock()
+	defer t.data.mu.RUnlock()
+	answer := make(map[string]internal.Department, len(t.data.departments))
+	for id, d := range t.data.departments {
+		answer[id] = d
+	}
+	return answer
+}
+
+func (t Repository) AddDepartment(d *internal.Department) {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	t.data.departments[d.ID.String()] = *d
+}
+
+func (t Repository) DeleteDepartment(id string) error {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	if _, ok := t.data.departments[id]; ok {
+		delete(t.data.departments, id)
+		return nil
+	}
+	return errors.NotFound()
+}
+
+func (t Repository) UpdateDepartment(d *internal.Department) error {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	if _, ok := t.data.departments[d.ID.String()]; ok {
+		t.data.departments[d.ID.String()] = *d
+		return nil
+	}
+	return errors.NotFound()
+}
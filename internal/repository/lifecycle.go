@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"github.com/VTerenya/employees/internal"
+)
+
+func (t Repository) GetEvents(employeeID string) []internal.LifecycleEvent {
+	t.data.mu.RLock()
+	defer t.data.mu.RUnlock()
+	events := t.data.events[employeeID]
+	answer := make([]internal.LifecycleEvent, len(events))
+	copy(answer, events)
+	return answer
+}
+
+func (t Repository) GetAllEvents() []internal.LifecycleEvent {
+	t.data.mu.RLock()
+	defer t.data.mu.RUnlock()
+	answer := make([]internal.LifecycleEvent, 0)
+	for _, events := range t.data.events {
+		answer = append(answer, events...)
+	}
+	return answer
+}
+
+func (t Repository) AddEvent(e *internal.LifecycleEvent) {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	key := e.EmployeeID.String()
+	t.data.events[key] = append(t.data.events[key], *e)
+	t.data.version++
+}
+
+// tombstones keep the last state of deleted records so that reports over
+// past periods still see them.
+type tombstones struct {
+	employees map[string]internal.Employee
+	positions map[string]internal.Position
+}
+
+func (t Repository) GetDeletedEmployees() map[string]internal.Employee {
+	t.data.mu.RLock()
+	defer t.data.mu.RUnlock()
+	answer := make(map[string]internal.Employee, len(t.data.tombstones.employees))
+	for id, e := range t.data.tombstones.employees {
+		answer[id] = e
+	}
+	return answer
+}
+
+func (t Repository) GetDeletedPositions() map[string]internal.Position {
+	t.data.mu.RLock()
+	defer t.data.mu.RUnlock()
+	answer := make(map[string]internal.Position, len(t.data.tombstones.positions))
+	for id, p := range t.data.tombstones.positions {
+		answer[id] = p
+	}
+	return answer
+}
+
+// EmployeesVersion changes whenever an employee or lifecycle event is written.
+func (t Repository) EmployeesVersion() uint64 {
+	t.data.mu.RLock()
+	defer t.data.mu.RUnlock()
+	return t.data.version
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+)
+
+func (t Repository) GetPayGrades() map[string]internal.PayGrade {
+	t.data.mu.RLock()
+	defer t.data.mu.RUnlock()
+	answer := make(map[string]internal.PayGrade, len(t.data.payGrades))
+	for id, g := range t.data.payGrades {
+		answer[id] = g
+	}
+	return answer
+}
+
+func (t Repository) AddPayGrade(g *internal.PayGrade) {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	t.data.payGrades[g.ID.String()] = *g
+}
+
+func (t Repository) DeletePayGrade(id string) error {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	if _, ok := t.data.payGrades[id]; ok {
+		delete(t.data.payGrades, id)
+		return nil
+	}
+	return errors.NotFound()
+}
+
+func (t Repository) UpdatePayGrade(g *internal.PayGrade) error {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	if _, ok := t.data.payGrades[g.ID.String()]; ok {
+		t.data.payGrades[g.ID.String()] = *g
+		return nil
+	}
+	return errors.NotFound()
+}
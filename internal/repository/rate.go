@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+)
+
+func (t Repository) GetExchangeRates() []internal.ExchangeRate {
+	t.data.mu.RLock()
+	defer t.data.mu.RUnlock()
+	answer := make([]internal.ExchangeRate, 0, len(t.data.rates))
+	for _, r := range t.data.rates {
+		answer = append(answer, r)
+	}
+	return answer
+}
+
+func (t Repository) SetExchangeRate(r *internal.ExchangeRate) {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	for id, value := range t.data.rates {
+		if value.Currency == r.Currency && value.EffectiveDate.Equal(r.EffectiveDate.Time) {
+			delete(t.data.rates, id)
+		}
+	}
+	t.data.rates[r.ID.String()] = *r
+}
+
+func (t Repository) DeleteExchangeRate(id string) error {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	if _, ok := t.data.rates[id]; ok {
+		delete(t.data.rates, id)
+		return nil
+	}
+	return errors.NotFound()
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"sync"
+
 	"github.com/VTerenya/employees/internal"
 	"github.com/VTerenya/employees/internal/errors"
 )
@@ -14,23 +16,43 @@ func NewRepo(data *Database) *Repository {
 }
 
 func (t Repository) GetPositions() map[string]internal.Position {
-	return t.data.GetPosition()
+	t.data.mu.RLock()
+	defer t.data.mu.RUnlock()
+	answer := make(map[string]internal.Position, len(t.data.positions))
+	for id, p := range t.data.positions {
+		answer[id] = p
+	}
+	return answer
 }
 
 func (t Repository) GetEmployees() map[string]internal.Employee {
-	return t.data.GetEmployees()
+	t.data.mu.RLock()
+	defer t.data.mu.RUnlock()
+	answer := make(map[string]internal.Employee, len(t.data.employees))
+	for id, e := range t.data.employees {
+		answer[id] = e
+	}
+	return answer
 }
 
 func (t Repository) AddPosition(p *internal.Position) {
-	t.data.GetPosition()[p.ID.String()] = *p
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	t.data.positions[p.ID.String()] = *p
 }
 
 func (t Repository) AddEmployee(e *internal.Employee) {
-	t.data.GetEmployees()[e.ID.String()] = *e
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	t.data.employees[e.ID.String()] = *e
+	t.data.version++
 }
 
 func (t Repository) DeletePosition(id string) error {
-	if _, ok := t.data.positions[id]; ok {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	if p, ok := t.data.positions[id]; ok {
+		t.data.tombstones.positions[id] = p
 		delete(t.data.positions, id)
 		return nil
 	}
@@ -38,14 +60,20 @@ func (t Repository) DeletePosition(id string) error {
 }
 
 func (t Repository) DeleteEmployee(id string) error {
-	if _, ok := t.data.employees[id]; ok {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
+	if e, ok := t.data.employees[id]; ok {
+		t.data.tombstones.employees[id] = e
 		delete(t.data.employees, id)
+		t.data.version++
 		return nil
 	}
 	return errors.NotFound()
 }
 
 func (t Repository) UpdatePosition(p *internal.Position) error {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
 	if _, ok := t.data.positions[p.ID.String()]; ok {
 		t.data.positions[p.ID.String()] = *p
 		return nil
@@ -54,9 +82,12 @@ func (t Repository) UpdatePosition(p *internal.Position) error {
 }
 
 func (t Repository) UpdateEmployee(e *internal.Employee) error {
+	t.data.mu.Lock()
+	defer t.data.mu.Unlock()
 	if _, ok := t.data.employees[e.ID.String()]; ok {
 		if _, ok1 := t.data.positions[e.PositionID.String()]; ok1 {
 			t.data.employees[e.ID.String()] = *e
+			t.data.version++
 			return nil
 		}
 		return errors.PositionIsNotExists()
@@ -65,21 +96,33 @@ func (t Repository) UpdateEmployee(e *internal.Employee) error {
 }
 
 type Database struct {
-	employees map[string]internal.Employee
-	positions map[string]internal.Position
+	employees   map[string]internal.Employee
+	positions   map[string]internal.Position
+	departments map[string]internal.Department
+	payGrades   map[string]internal.PayGrade
+	events      map[string][]internal.LifecycleEvent
+	attributes  map[string]internal.AttributeDefinition
+	rates       map[string]internal.ExchangeRate
+	apiKeys     map[string]internal.APIKey
+	tombstones  tombstones
+	version     uint64
+	mu          *sync.RWMutex
 }
 
 func NewDataBase() *Database {
 	return &Database{
-		employees: map[string]internal.Employee{},
-		positions: map[string]internal.Position{},
+		employees:   map[string]internal.Employee{},
+		positions:   map[string]internal.Position{},
+		departments: map[string]internal.Department{},
+		payGrades:   map[string]internal.PayGrade{},
+		events:      map[string][]internal.LifecycleEvent{},
+		attributes:  map[string]internal.AttributeDefinition{},
+		rates:       map[string]internal.ExchangeRate{},
+		apiKeys:     map[string]internal.APIKey{},
+		tombstones: tombstones{
+			employees: map[string]internal.Employee{},
+			positions: map[string]internal.Position{},
+		},
+		mu: &sync.RWMutex{},
 	}
 }
-
-func (d Database) GetEmployees() map[string]internal.Employee {
-	return d.employees
-}
-
-func (d Database) GetPosition() map[string]internal.Position {
-	return d.positions
-}
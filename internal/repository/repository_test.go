@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/google/uuid"
+)
+
+func TestConcurrentEmployeeAccess(t *testing.T) {
+	repo := NewRepo(NewDataBase())
+	position := internal.Position{ID: uuid.New(), Name: "dev"}
+	repo.AddPosition(&position)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				e := internal.Employee{ID: uuid.New(), PositionID: position.ID}
+				repo.AddEmployee(&e)
+				e.FirstName = "updated"
+				if err := repo.UpdateEmployee(&e); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				for id := range repo.GetEmployees() {
+					_ = repo.GetPositions()[id]
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if n := len(repo.GetEmployees()); n != 800 {
+		t.Fatalf("got %d employees, want 800", n)
+	}
+}
+
+func TestGetEmployeesReturnsCopy(t *testing.T) {
+	repo := NewRepo(NewDataBase())
+	e := internal.Employee{ID: uuid.New()}
+	repo.AddEmployee(&e)
+	delete(repo.GetEmployees(), e.ID.String())
+	if _, ok := repo.GetEmployees()[e.ID.String()]; !ok {
+		t.Fatal("mutating the returned map changed the repository")
+	}
+}
+
+func TestDeleteAttributeDefinitionStripsValues(t *testing.T) {
+	repo := NewRepo(NewDataBase())
+	d := internal.AttributeDefinition{ID: uuid.New(), Entity: internal.EntityEmployee, Name: "badge",
+		Type: internal.AttributeString}
+	repo.AddAttributeDefinition(&d)
+	position := internal.Position{ID: uuid.New(), Name: "dev", Attributes: internal.Attributes{"badge": "x"}}
+	repo.AddPosition(&position)
+	kept := internal.Employee{ID: uuid.New(), PositionID: position.ID,
+		Attributes: internal.Attributes{"badge": "b1", "floor": 3.0}}
+	emptied := internal.Employee{ID: uuid.New(), PositionID: position.ID, Attributes: internal.Attributes{"badge": "b2"}}
+	repo.AddEmployee(&kept)
+	repo.AddEmployee(&emptied)
+	if err := repo.DeleteAttributeDefinition(d.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+	employees := repo.GetEmployees()
+	if got := employees[kept.ID.String()].Attributes; len(got) != 1 || got["floor"] != 3.0 {
+		t.Fatalf("attributes of the first employee = %v", got)
+	}
+	if got := employees[emptied.ID.String()].Attributes; got != nil {
+		t.Fatalf("attributes of the second employee = %v", got)
+	}
+	if got := repo.GetPositions()[position.ID.String()].Attributes; got["badge"] != "x" {
+		t.Fatalf("position attribute of another entity removed: %v", got)
+	}
+	if err := repo.DeleteAttributeDefinition(d.ID.String()); err == nil {
+		t.Fatal("deleting twice succeeded")
+	}
+}
@@ -0,0 +1,50 @@
+package requestctx
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+const CorrelationIDKey = "correlation_id"
+
+type contextKey int
+
+const (
+	correlationIDContextKey contextKey = iota
+	loggerContextKey
+)
+
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDContextKey, id)
+}
+
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDContextKey).(string)
+	return id
+}
+
+type requestLogger struct {
+	entry *logrus.Entry
+}
+
+func Logger(ctx context.Context) *logrus.Entry {
+	if l, ok := ctx.Value(loggerContextKey).(*requestLogger); ok {
+		return l.entry
+	}
+	entry := logrus.NewEntry(logrus.StandardLogger())
+	if id := CorrelationID(ctx); id != "" {
+		entry = entry.WithField(CorrelationIDKey, id)
+	}
+	return entry
+}
+
+func AddLogFields(ctx context.Context, fields logrus.Fields) {
+	if l, ok := ctx.Value(loggerContextKey).(*requestLogger); ok {
+		l.entry = l.entry.WithFields(fields)
+	}
+}
+
+func WithLogger(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, loggerContextKey, &requestLogger{entry: entry})
+}
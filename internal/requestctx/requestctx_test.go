@@ -0,0 +1,36 @@
+package requestctx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestLoggerCarriesCorrelationID(t *testing.T) {
+	ctx := context.Background()
+	if _, ok := Logger(ctx).Data[CorrelationIDKey]; ok {
+		t.Fatal("logger without a correlation id has the field")
+	}
+	ctx = WithCorrelationID(ctx, "req-1")
+	if got := CorrelationID(ctx); got != "req-1" {
+		t.Fatalf("correlation id = %q", got)
+	}
+	if got := Logger(ctx).Data[CorrelationIDKey]; got != "req-1" {
+		t.Fatalf("logger field = %v", got)
+	}
+}
+
+func TestAddLogFields(t *testing.T) {
+	ctx := WithCorrelationID(context.Background(), "req-1")
+	AddLogFields(ctx, logrus.Fields{"user": "ann"})
+	if _, ok := Logger(ctx).Data["user"]; ok {
+		t.Fatal("fields were added without a request logger")
+	}
+	ctx = WithLogger(ctx, logrus.NewEntry(logrus.StandardLogger()).WithField(CorrelationIDKey, "req-1"))
+	AddLogFields(ctx, logrus.Fields{"user": "ann"})
+	entry := Logger(ctx)
+	if entry.Data["user"] != "ann" || entry.Data[CorrelationIDKey] != "req-1" {
+		t.Fatalf("logger fields = %v", entry.Data)
+	}
+}
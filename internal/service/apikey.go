@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"sort"
+	"time"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/google/uuid"
+)
+
+const (
+	apiKeyPrefix      = "emp_"
+	apiKeyBytes       = 32
+	apiKeyPrefixChars = 8
+)
+
+func HashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func newAPIKeySecret() (string, error) {
+	b := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (t Serv) issueSecret(k *internal.APIKey) (string, error) {
+	secret, err := newAPIKeySecret()
+	if err != nil {
+		return "", errors.StatusInternalServerError()
+	}
+	k.Hash = HashAPIKey(secret)
+	k.Prefix = secret[:len(apiKeyPrefix)+apiKeyPrefixChars]
+	return secret, nil
+}
+
+func (t Serv) CreateAPIKey(ctx context.Context, k *internal.APIKey) (string, error) {
+	err := logOperation(ctx, "CreateAPIKey")
+	if err != nil {
+		return "", err
+	}
+	err = authorize(ctx, auth.PermAPIKeysAdmin)
+	if err != nil {
+		return "", err
+	}
+	if k.Name == "" || len(k.Scopes) == 0 {
+		return "", errors.BadRequest()
+	}
+	for _, scope := range k.Scopes {
+		if !auth.KnownPermission(scope) {
+			return "", errors.BadRequest()
+		}
+	}
+	for _, scope := range k.Scopes {
+		if !auth.Can(ctx, scope) {
+			return "", errors.Forbidden()
+		}
+	}
+	now := time.Now().UTC()
+	if k.ExpiresAt != nil && !k.ExpiresAt.After(now) {
+		return "", errors.BadRequest()
+	}
+	secret, err := t.issueSecret(k)
+	if err != nil {
+		return "", err
+	}
+	k.ID = uuid.New()
+	k.CreatedAt = now
+	k.RotatedAt, k.LastUsedAt, k.RevokedAt = nil, nil, nil
+	t.repo.AddAPIKey(k)
+	return secret, nil
+}
+
+func (t Serv) GetAPIKeys(ctx context.Context) ([]internal.APIKey, error) {
+	err := logOperation(ctx, "GetAPIKeys")
+	if err != nil {
+		return nil, err
+	}
+	err = authorize(ctx, auth.PermAPIKeysAdmin)
+	if err != nil {
+		return nil, err
+	}
+	keys := t.repo.GetAPIKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+func (t Serv) RotateAPIKey(ctx context.Context, id string) (internal.APIKey, string, error) {
+	err := logOperation(ctx, "RotateAPIKey")
+	if err != nil {
+		return internal.APIKey{}, "", err
+	}
+	err = authorize(ctx, auth.PermAPIKeysAdmin)
+	if err != nil {
+		return internal.APIKey{}, "", err
+	}
+	k, err := t.repo.GetAPIKey(id)
+	if err != nil {
+		return internal.APIKey{}, "", err
+	}
+	if k.RevokedAt != nil {
+		return internal.APIKey{}, "", errors.APIKeyIsRevoked()
+	}
+	secret, err := t.issueSecret(&k)
+	if err != nil {
+		return internal.APIKey{}, "", err
+	}
+	now := time.Now().UTC()
+	k.RotatedAt = &now
+	err = t.repo.UpdateAPIKey(&k)
+	if err != nil {
+		return internal.APIKey{}, "", err
+	}
+	return k, secret, nil
+}
+
+func (t Serv) RevokeAPIKey(ctx context.Context, id string) error {
+	err := logOperation(ctx, "RevokeAPIKey")
+	if err != nil {
+		return err
+	}
+	err = authorize(ctx, auth.PermAPIKeysAdmin)
+	if err != nil {
+		return err
+	}
+	k, err := t.repo.GetAPIKey(id)
+	if err != nil {
+		return err
+	}
+	if k.RevokedAt != nil {
+		return nil
+	}
+	now := time.Now().UTC()
+	k.RevokedAt = &now
+	return t.repo.UpdateAPIKey(&k)
+}
+
+func (t Serv) AuthenticateAPIKey(ctx context.Context, secret string) (internal.APIKey, error) {
+	err := logOperation(ctx, "AuthenticateAPIKey")
+	if err != nil {
+		return internal.APIKey{}, err
+	}
+	k, err := t.repo.FindAPIKeyByHash(HashAPIKey(secret))
+	now := time.Now().UTC()
+	if err != nil || k.RevokedAt != nil || (k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)) {
+		return internal.APIKey{}, errors.Unauthorized()
+	}
+	err = t.repo.TouchAPIKey(k.ID.String(), now)
+	if err != nil {
+		return internal.APIKey{}, err
+	}
+	return k, nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	errs "errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/errors"
+)
+
+func createAPIKey(t *testing.T, s *Serv, scopes ...string) (internal.APIKey, string) {
+	t.Helper()
+	k := internal.APIKey{Name: "batch", Scopes: scopes}
+	secret, err := s.CreateAPIKey(testContext(), &k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k, secret
+}
+
+func TestCreateAPIKeyStoresHash(t *testing.T) {
+	s := newTestServ()
+	k, secret := createAPIKey(t, s, auth.PermEmployeesRead)
+	if !strings.HasPrefix(secret, apiKeyPrefix) || !strings.HasPrefix(secret, k.Prefix) {
+		t.Fatalf("secret %q does not start with prefix %q", secret, k.Prefix)
+	}
+	stored, err := s.repo.GetAPIKey(k.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Hash != HashAPIKey(secret) || strings.Contains(stored.Hash, secret) {
+		t.Fatalf("stored hash %q does not match the secret", stored.Hash)
+	}
+	got, err := s.AuthenticateAPIKey(testContext(), secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != k.ID {
+		t.Fatalf("authenticated %+v", got)
+	}
+	if stored, _ = s.repo.GetAPIKey(k.ID.String()); stored.LastUsedAt == nil {
+		t.Fatal("last use not recorded")
+	}
+	if _, err := s.AuthenticateAPIKey(testContext(), secret+"x"); !errs.Is(err, errors.Unauthorized()) {
+		t.Fatalf("wrong secret: got %v", err)
+	}
+}
+
+func TestCreateAPIKeyValidatesScopes(t *testing.T) {
+	s := newTestServ()
+	admin := testContext(auth.PermAPIKeysAdmin, auth.PermEmployeesRead)
+	for _, tt := range []struct {
+		name   string
+		scopes []string
+		want   error
+	}{
+		{"held scope", []string{auth.PermEmployeesRead}, nil},
+		{"no scopes", nil, errors.BadRequest()},
+		{"unknown scope", []string{"employees:delete"}, errors.BadRequest()},
+		{"scope the caller lacks", []string{auth.PermEmployeesRead, auth.PermSalaryRead}, errors.Forbidden()},
+	} {
+		k := internal.APIKey{Name: "batch", Scopes: tt.scopes}
+		_, err := s.CreateAPIKey(admin, &k)
+		if tt.want == nil && err != nil || tt.want != nil && !errs.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	k := internal.APIKey{Name: "batch", Scopes: []string{auth.PermEmployeesRead}}
+	if _, err := s.CreateAPIKey(testContext(auth.PermEmployeesRead), &k); !errs.Is(err, errors.Forbidden()) {
+		t.Fatalf("caller without apikeys:admin: got %v", err)
+	}
+}
+
+func TestAuthenticateAPIKeyRejectsRevokedExpiredAndRotated(t *testing.T) {
+	s := newTestServ()
+	revoked, revokedSecret := createAPIKey(t, s, auth.PermEmployeesRead)
+	if err := s.RevokeAPIKey(testContext(), revoked.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AuthenticateAPIKey(testContext(), revokedSecret); !errs.Is(err, errors.Unauthorized()) {
+		t.Fatalf("revoked key: got %v", err)
+	}
+	if _, _, err := s.RotateAPIKey(testContext(), revoked.ID.String()); !errs.Is(err, errors.APIKeyIsRevoked()) {
+		t.Fatalf("rotating a revoked key: got %v", err)
+	}
+
+	expired, expiredSecret := createAPIKey(t, s, auth.PermEmployeesRead)
+	past := time.Now().UTC().Add(-time.Minute)
+	expired.ExpiresAt = &past
+	if err := s.repo.UpdateAPIKey(&expired); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AuthenticateAPIKey(testContext(), expiredSecret); !errs.Is(err, errors.Unauthorized()) {
+		t.Fatalf("expired key: got %v", err)
+	}
+	k := internal.APIKey{Name: "late", Scopes: []string{auth.PermEmployeesRead}, ExpiresAt: &past}
+	if _, err := s.CreateAPIKey(testContext(), &k); !errs.Is(err, errors.BadRequest()) {
+		t.Fatalf("creating an already expired key: got %v", err)
+	}
+
+	rotated, oldSecret := createAPIKey(t, s, auth.PermEmployeesRead)
+	_, newSecret, err := s.RotateAPIKey(testContext(), rotated.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AuthenticateAPIKey(testContext(), oldSecret); !errs.Is(err, errors.Unauthorized()) {
+		t.Fatalf("secret replaced by rotation: got %v", err)
+	}
+	if _, err := s.AuthenticateAPIKey(testContext(), newSecret); err != nil {
+		t.Fatalf("rotated secret: %v", err)
+	}
+}
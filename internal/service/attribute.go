@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/google/uuid"
+)
+
+var attributeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`) // nolint: gochecknoglobals
+
+func (t Serv) GetAttributeDefinitions(ctx context.Context, entity string) ([]internal.AttributeDefinition, error) {
+	err := logOperation(ctx, "GetAttributeDefinitions")
+	if err != nil {
+		return nil, err
+	}
+	if entity != "" && !internal.ValidEntity(entity) {
+		return nil, errors.BadRequest()
+	}
+	if entity != "" {
+		err = authorize(ctx, entityReadPermission(entity))
+		if err != nil {
+			return nil, err
+		}
+	}
+	answer := make([]internal.AttributeDefinition, 0)
+	for _, d := range t.repo.GetAttributeDefinitions() {
+		if entity != "" && d.Entity != entity || !auth.Can(ctx, entityReadPermission(d.Entity)) {
+			continue
+		}
+		answer = append(answer, d)
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		if answer[i].Entity != answer[j].Entity {
+			return answer[i].Entity < answer[j].Entity
+		}
+		return answer[i].Name < answer[j].Name
+	})
+	return answer, nil
+}
+
+func (t Serv) CreateAttributeDefinition(ctx context.Context, d *internal.AttributeDefinition) error {
+	err := logOperation(ctx, "CreateAttributeDefinition")
+	if err != nil {
+		return err
+	}
+	err = authorize(ctx, auth.PermAttributesAdmin)
+	if err != nil {
+		return err
+	}
+	err = checkAttributeDefinition(d)
+	if err != nil {
+		return err
+	}
+	for _, value := range t.repo.GetAttributeDefinitions() {
+		if value.Entity == d.Entity && value.Name == d.Name {
+			return errors.AttributeIsExists()
+		}
+	}
+	d.ID = uuid.New()
+	t.repo.AddAttributeDefinition(d)
+	return nil
+}
+
+func (t Serv) UpdateAttributeDefinition(ctx context.Context, d *internal.AttributeDefinition) error {
+	err := logOperation(ctx, "UpdateAttributeDefinition")
+	if err != nil {
+		return err
+	}
+	err = authorize(ctx, auth.PermAttributesAdmin)
+	if err != nil {
+		return err
+	}
+	if d.ID == uuid.Nil {
+		return errors.BadRequest()
+	}
+	current, ok := t.attributeDefinition(d.ID.String())
+	if !ok {
+		return errors.NotFound()
+	}
+	if d.Entity != current.Entity || d.Name != current.Name {
+		return errors.BadRequest()
+	}
+	err = checkAttributeDefinition(d)
+	if err != nil {
+		return err
+	}
+	for _, attrs := range t.storedAttributes(d.Entity) {
+		if v, ok := attrs[d.Name]; ok {
+			if _, err = attributeValue(*d, v); err != nil {
+				return err
+			}
+		}
+	}
+	return t.repo.UpdateAttributeDefinition(d)
+}
+
+func (t Serv) DeleteAttributeDefinition(ctx context.Context, id string) error {
+	err := logOperation(ctx, "DeleteAttributeDefinition")
+	if err != nil {
+		return err
+	}
+	err = authorize(ctx, auth.PermAttributesAdmin)
+	if err != nil {
+		return err
+	}
+	d, ok := t.attributeDefinition(idKey(id))
+	if !ok {
+		return errors.NotFound()
+	}
+	return t.repo.DeleteAttributeDefinition(d.ID.String())
+}
+
+func (t Serv) attributeDefinition(id string) (internal.AttributeDefinition, bool) {
+	for _, d := range t.repo.GetAttributeDefinitions() {
+		if d.ID.String() == id {
+			return d, true
+		}
+	}
+	return internal.AttributeDefinition{}, false
+}
+
+func (t Serv) attributeDefinitions(entity string) map[string]internal.AttributeDefinition {
+	answer := make(map[string]internal.AttributeDefinition)
+	for _, d := range t.repo.GetAttributeDefinitions() {
+		if d.Entity == entity {
+			answer[d.Name] = d
+		}
+	}
+	return answer
+}
+
+func (t Serv) storedAttributes(entity string) []internal.Attributes {
+	answer := make([]internal.Attributes, 0)
+	switch entity {
+	case internal.EntityEmployee:
+		for _, e := range t.repo.GetEmployees() {
+			answer = append(answer, e.Attributes)
+		}
+	case internal.EntityPosition:
+		for _, p := range t.repo.GetPositions() {
+			answer = append(answer, p.Attributes)
+		}
+	}
+	return answer
+}
+
+func (t Serv) checkAttributes(entity string, attrs internal.Attributes) (internal.Attributes, error) {
+	definitions := t.attributeDefinitions(entity)
+	answer := make(internal.Attributes, len(attrs))
+	for name, v := range attrs {
+		d, ok := definitions[name]
+		if !ok {
+			return nil, errors.InvalidAttribute()
+		}
+		if v == nil {
+			continue
+		}
+		value, err := attributeValue(d, v)
+		if err != nil {
+			return nil, err
+		}
+		answer[name] = value
+	}
+	for name, d := range definitions {
+		if _, ok := answer[name]; d.Required && !ok {
+			return nil, errors.InvalidAttribute()
+		}
+	}
+	if len(answer) == 0 {
+		return nil, nil
+	}
+	return answer, nil
+}
+
+func (t Serv) checkAttributeFilter(entity string, filter map[string]string) error {
+	definitions := t.attributeDefinitions(entity)
+	for name := range filter {
+		if _, ok := definitions[name]; !ok {
+			return errors.BadRequest()
+		}
+	}
+	return nil
+}
+
+func checkAttributeDefinition(d *internal.AttributeDefinition) error {
+	if !internal.ValidEntity(d.Entity) || !attributeNamePattern.MatchString(d.Name) ||
+		!internal.ValidAttributeType(d.Type) {
+		return errors.BadRequest()
+	}
+	switch d.Type {
+	case internal.AttributeEnum:
+		if len(d.AllowedValues) == 0 {
+			return errors.BadRequest()
+		}
+	case internal.AttributeString:
+	default:
+		if len(d.AllowedValues) != 0 {
+			return errors.BadRequest()
+		}
+	}
+	seen := make(map[string]bool, len(d.AllowedValues))
+	for _, v := range d.AllowedValues {
+		if v == "" || seen[v] {
+			return errors.BadRequest()
+		}
+		seen[v] = true
+	}
+	return nil
+}
+
+func attributeValue(d internal.AttributeDefinition, v interface{}) (interface{}, error) {
+	switch d.Type {
+	case internal.AttributeNumber:
+		switch n := v.(type) {
+		case float64:
+			return n, nil
+		case int:
+			return float64(n), nil
+		}
+	case internal.AttributeBoolean:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case internal.AttributeDate:
+		if s, ok := v.(string); ok {
+			date, err := internal.ParseDate(s)
+			if err == nil {
+				return date.String(), nil
+			}
+		}
+	case internal.AttributeString, internal.AttributeEnum:
+		if s, ok := v.(string); ok && allowedValue(d.AllowedValues, s) {
+			return s, nil
+		}
+	}
+	return nil, errors.InvalidAttribute()
+}
+
+func allowedValue(allowed []string, s string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, v := range allowed {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func attributesMatch(attrs internal.Attributes, filter map[string]string) bool {
+	for name, want := range filter {
+		v, ok := attrs[name]
+		if !ok || formatAttribute(v) != want {
+			return false
+		}
+	}
+	return true
+}
+
+func formatAttribute(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case bool:
+		return strconv.FormatBool(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return ""
+}
+
+func entityReadPermission(entity string) string {
+	if entity == internal.EntityPosition {
+		return auth.PermPositionsRead
+	}
+	return auth.PermEmployeesRead
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	errs "errors"
+	"testing"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+)
+
+func TestAttributeDefinitionValidation(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		d    internal.AttributeDefinition
+		ok   bool
+	}{
+		{"string", internal.AttributeDefinition{Entity: internal.EntityEmployee, Name: "badge", Type: "string"}, true},
+		{"enum", internal.AttributeDefinition{Entity: internal.EntityPosition, Name: "level", Type: "enum",
+			AllowedValues: []string{"junior", "senior"}}, true},
+		{"string with allowed values", internal.AttributeDefinition{Entity: internal.EntityEmployee, Name: "site",
+			Type: "string", AllowedValues: []string{"minsk"}}, true},
+		{"unknown entity", internal.AttributeDefinition{Entity: "team", Name: "badge", Type: "string"}, false},
+		{"unknown type", internal.AttributeDefinition{Entity: internal.EntityEmployee, Name: "badge", Type: "list"}, false},
+		{"capitalised name", internal.AttributeDefinition{Entity: internal.EntityEmployee, Name: "Badge", Type: "string"},
+			false},
+		{"name with a dash", internal.AttributeDefinition{Entity: internal.EntityEmployee, Name: "cost-center",
+			Type: "string"}, false},
+		{"enum without values", internal.AttributeDefinition{Entity: internal.EntityPosition, Name: "level",
+			Type: "enum"}, false},
+		{"number with values", internal.AttributeDefinition{Entity: internal.EntityPosition, Name: "floor",
+			Type: "number", AllowedValues: []string{"1"}}, false},
+		{"duplicate values", internal.AttributeDefinition{Entity: internal.EntityPosition, Name: "level",
+			Type: "enum", AllowedValues: []string{"junior", "junior"}}, false},
+		{"empty value", internal.AttributeDefinition{Entity: internal.EntityPosition, Name: "level",
+			Type: "enum", AllowedValues: []string{""}}, false},
+	} {
+		s := newTestServ()
+		d := tt.d
+		err := s.CreateAttributeDefinition(testContext(), &d)
+		if tt.ok && err != nil || !tt.ok && !errs.Is(err, errors.BadRequest()) {
+			t.Errorf("%s: got %v", tt.name, err)
+		}
+	}
+	s := newTestServ()
+	for i := 0; i < 2; i++ {
+		d := internal.AttributeDefinition{Entity: internal.EntityEmployee, Name: "badge", Type: "string"}
+		err := s.CreateAttributeDefinition(testContext(), &d)
+		if i == 1 && !errs.Is(err, errors.AttributeIsExists()) {
+			t.Fatalf("duplicate definition: got %v", err)
+		}
+	}
+}
+
+func TestAttributeValueValidation(t *testing.T) {
+	definitions := map[string]internal.AttributeDefinition{
+		"floor":  {Type: internal.AttributeNumber},
+		"remote": {Type: internal.AttributeBoolean},
+		"review": {Type: internal.AttributeDate},
+		"level":  {Type: internal.AttributeEnum, AllowedValues: []string{"junior", "senior"}},
+		"badge":  {Type: internal.AttributeString},
+	}
+	for _, tt := range []struct {
+		attribute string
+		value     interface{}
+		want      interface{}
+	}{
+		{"floor", 3.5, 3.5},
+		{"floor", 3, 3.0},
+		{"floor", "3", nil},
+		{"remote", true, true},
+		{"remote", "true", nil},
+		{"review", "2024-02-29", "2024-02-29"},
+		{"review", "2023-02-29", nil},
+		{"review", "29.02.2024", nil},
+		{"level", "senior", "senior"},
+		{"level", "lead", nil},
+		{"badge", "b-17", "b-17"},
+		{"badge", 17.0, nil},
+	} {
+		got, err := attributeValue(definitions[tt.attribute], tt.value)
+		if tt.want == nil {
+			if !errs.Is(err, errors.InvalidAttribute()) {
+				t.Errorf("%s = %v: got %v, %v, want invalid", tt.attribute, tt.value, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%s = %v: got %v, %v, want %v", tt.attribute, tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestEmployeeAttributes(t *testing.T) {
+	s := newTestServ()
+	for _, d := range []internal.AttributeDefinition{
+		{Entity: internal.EntityEmployee, Name: "badge", Type: "string", Required: true},
+		{Entity: internal.EntityEmployee, Name: "level", Type: "enum", AllowedValues: []string{"junior", "senior"}},
+	} {
+		d := d
+		if err := s.CreateAttributeDefinition(testContext(), &d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	position := addPosition(t, s, "dev").ID
+	create := func(first string, attrs internal.Attributes) error {
+		return s.CreateEmployee(testContext(), &internal.Employee{FirstName: first, LastName: first,
+			PositionID: position, Attributes: attrs})
+	}
+	if err := create("ann", internal.Attributes{"level": "junior"}); !errs.Is(err, errors.InvalidAttribute()) {
+		t.Fatalf("missing required attribute: got %v", err)
+	}
+	if err := create("ann", internal.Attributes{"badge": "1", "shoe": "42"}); !errs.Is(err, errors.InvalidAttribute()) {
+		t.Fatalf("undefined attribute: got %v", err)
+	}
+	if err := create("ann", internal.Attributes{"badge": "1", "level": "lead"}); !errs.Is(err, errors.InvalidAttribute()) {
+		t.Fatalf("value outside the enum: got %v", err)
+	}
+	if err := create("ann", internal.Attributes{"badge": "1", "level": "senior"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := create("bob", internal.Attributes{"badge": "2", "level": "junior"}); err != nil {
+		t.Fatal(err)
+	}
+	seniors, err := s.GetEmployees(testContext(), 10, 1,
+		internal.EmployeeFilter{Attributes: map[string]string{"level": "senior"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seniors) != 1 || seniors[0].FirstName != "ann" {
+		t.Fatalf("filtered employees = %v", seniors)
+	}
+	_, err = s.GetEmployees(testContext(), 10, 1, internal.EmployeeFilter{Attributes: map[string]string{"shoe": "42"}})
+	if !errs.Is(err, errors.BadRequest()) {
+		t.Fatalf("filter on an undefined attribute: got %v", err)
+	}
+}
+
+func TestDeleteAttributeDefinitionRemovesValues(t *testing.T) {
+	s := newTestServ()
+	d := internal.AttributeDefinition{Entity: internal.EntityEmployee, Name: "badge", Type: "string"}
+	if err := s.CreateAttributeDefinition(testContext(), &d); err != nil {
+		t.Fatal(err)
+	}
+	e := internal.Employee{FirstName: "ann", LastName: "ann", PositionID: addPosition(t, s, "dev").ID,
+		Attributes: internal.Attributes{"badge": "1"}}
+	if err := s.CreateEmployee(testContext(), &e); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteAttributeDefinition(testContext(), d.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.GetEmployee(testContext(), e.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Attributes != nil {
+		t.Fatalf("attributes after deleting the definition = %v", got.Attributes)
+	}
+	if err := s.DeleteAttributeDefinition(testContext(), d.ID.String()); !errs.Is(err, errors.NotFound()) {
+		t.Fatalf("deleting twice: got %v", err)
+	}
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const conversionPlaces = 16
+
+func (t Serv) BaseCurrency() string {
+	return t.baseCurrency
+}
+
+func (t Serv) GetExchangeRates(ctx context.Context, currency string) ([]internal.ExchangeRate, error) {
+	err := logOperation(ctx, "GetExchangeRates")
+	if err != nil {
+		return nil, err
+	}
+	err = authorize(ctx, auth.PermPositionsRead)
+	if err != nil {
+		return nil, err
+	}
+	if currency != "" && !internal.ValidCurrency(currency) {
+		return nil, errors.BadRequest()
+	}
+	answer := make([]internal.ExchangeRate, 0)
+	for _, r := range t.repo.GetExchangeRates() {
+		if currency == "" || r.Currency == currency {
+			answer = append(answer, r)
+		}
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		if answer[i].Currency != answer[j].Currency {
+			return answer[i].Currency < answer[j].Currency
+		}
+		return answer[i].EffectiveDate.After(answer[j].EffectiveDate.Time)
+	})
+	return answer, nil
+}
+
+func (t Serv) SetExchangeRate(ctx context.Context, r *internal.ExchangeRate) error {
+	err := logOperation(ctx, "SetExchangeRate")
+	if err != nil {
+		return err
+	}
+	err = authorize(ctx, auth.PermRatesAdmin)
+	if err != nil {
+		return err
+	}
+	return t.setExchangeRate(r)
+}
+
+func (t Serv) DeleteExchangeRate(ctx context.Context, id string) error {
+	err := logOperation(ctx, "DeleteExchangeRate")
+	if err != nil {
+		return err
+	}
+	err = authorize(ctx, auth.PermRatesAdmin)
+	if err != nil {
+		return err
+	}
+	return t.repo.DeleteExchangeRate(idKey(id))
+}
+
+func (t Serv) LoadExchangeRates(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var rates []internal.ExchangeRate
+	err = json.Unmarshal(data, &rates)
+	if err != nil {
+		return err
+	}
+	for i := range rates {
+		err = t.setExchangeRate(&rates[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t Serv) setExchangeRate(r *internal.ExchangeRate) error {
+	if !internal.ValidCurrency(r.Currency) || r.Currency == t.baseCurrency || !r.Rate.IsPositive() {
+		return errors.BadRequest()
+	}
+	if r.EffectiveDate.IsZero() {
+		r.EffectiveDate = internal.Today()
+	}
+	r.ID = uuid.New()
+	for _, value := range t.repo.GetExchangeRates() {
+		if value.Currency == r.Currency && value.EffectiveDate.Equal(r.EffectiveDate.Time) {
+			r.ID = value.ID
+		}
+	}
+	r.RecordedAt = time.Now().UTC()
+	t.repo.SetExchangeRate(r)
+	return nil
+}
+
+func (t Serv) ConvertPositions(ctx context.Context, positions []internal.Position, currency string) ([]internal.Position, error) {
+	err := logOperation(ctx, "ConvertPositions")
+	if err != nil {
+		return nil, err
+	}
+	if !internal.ValidCurrency(currency) {
+		return nil, errors.BadRequest()
+	}
+	today := internal.Today()
+	answer := make([]internal.Position, 0, len(positions))
+	for _, p := range positions {
+		p.Salary, err = t.convert(p.Salary, p.Currency, currency, today)
+		if err != nil {
+			return nil, err
+		}
+		p.Currency = currency
+		answer = append(answer, p)
+	}
+	return answer, nil
+}
+
+func (t Serv) ConvertPayGrades(ctx context.Context, grades []internal.PayGrade, currency string) ([]internal.PayGrade, error) {
+	err := logOperation(ctx, "ConvertPayGrades")
+	if err != nil {
+		return nil, err
+	}
+	if !internal.ValidCurrency(currency) {
+		return nil, errors.BadRequest()
+	}
+	today := internal.Today()
+	answer := make([]internal.PayGrade, 0, len(grades))
+	for _, g := range grades {
+		amounts := []*decimal.Decimal{&g.Min, &g.Mid, &g.Max}
+		err = t.convertAll(amounts, g.Currency, currency, today)
+		if err != nil {
+			return nil, err
+		}
+		g.Currency = currency
+		answer = append(answer, g)
+	}
+	return answer, nil
+}
+
+func (t Serv) ConvertCompaRatios(ctx context.Context, ratios []internal.CompaRatio, currency string) ([]internal.CompaRatio, error) {
+	err := logOperation(ctx, "ConvertCompaRatios")
+	if err != nil {
+		return nil, err
+	}
+	if !internal.ValidCurrency(currency) {
+		return nil, errors.BadRequest()
+	}
+	today := internal.Today()
+	answer := make([]internal.CompaRatio, 0, len(ratios))
+	for _, r := range ratios {
+		amounts := []*decimal.Decimal{&r.Salary, &r.Min, &r.Mid, &r.Max}
+		err = t.convertAll(amounts, r.Currency, currency, today)
+		if err != nil {
+			return nil, err
+		}
+		r.Currency = currency
+		answer = append(answer, r)
+	}
+	return answer, nil
+}
+
+func (t Serv) convertAll(amounts []*decimal.Decimal, from, to string, at internal.Date) error {
+	for _, amount := range amounts {
+		converted, err := t.convert(*amount, from, to, at)
+		if err != nil {
+			return err
+		}
+		*amount = converted
+	}
+	return nil
+}
+
+// convert rounds to the target currency's minor units, half away from zero;
+// rates are quoted as units of the currency per one unit of the base currency.
+func (t Serv) convert(amount decimal.Decimal, from, to string, at internal.Date) (decimal.Decimal, error) {
+	if from == to {
+		return amount, nil
+	}
+	fromRate, err := t.rate(from, at)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	toRate, err := t.rate(to, at)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return internal.RoundAmount(amount.Mul(toRate).DivRound(fromRate, conversionPlaces), to), nil
+}
+
+func (t Serv) rate(currency string, at internal.Date) (decimal.Decimal, error) {
+	if currency == t.baseCurrency {
+		return decimal.NewFromInt(1), nil
+	}
+	var found *internal.ExchangeRate
+	for _, r := range t.repo.GetExchangeRates() {
+		if r.Currency != currency || r.EffectiveDate.After(at.Time) {
+			continue
+		}
+		if found == nil || r.EffectiveDate.After(found.EffectiveDate.Time) {
+			r := r
+			found = &r
+		}
+	}
+	if found == nil {
+		return decimal.Decimal{}, errors.ExchangeRateIsNotExists()
+	}
+	return found.Rate, nil
+}
+
+func (t Serv) checkCurrency(currency *string, current string) error {
+	if *currency == "" {
+		*currency = current
+	}
+	if *currency == "" {
+		*currency = t.baseCurrency
+	}
+	if !internal.ValidCurrency(*currency) {
+		return errors.BadRequest()
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	errs "errors"
+	"testing"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/VTerenya/employees/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+func date(t *testing.T, s string) internal.Date {
+	t.Helper()
+	d, err := internal.ParseDate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func newRatesServ(t *testing.T) *Serv {
+	t.Helper()
+	s := NewServ(repository.NewRepo(repository.NewDataBase()), "USD")
+	for _, r := range []struct {
+		currency, rate, date string
+	}{
+		{"EUR", "0.9", "2024-01-01"},
+		{"EUR", "0.8", "2024-06-01"},
+		{"JPY", "150", "2024-01-01"},
+		{"BHD", "0.376", "2024-01-01"},
+	} {
+		rate := internal.ExchangeRate{Currency: r.currency, Rate: decimal.RequireFromString(r.rate),
+			EffectiveDate: date(t, r.date)}
+		if err := s.setExchangeRate(&rate); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return s
+}
+
+func TestConvert(t *testing.T) {
+	s := newRatesServ(t)
+	for _, tt := range []struct {
+		amount, from, to, at string
+		want                 string
+		err                  error
+	}{
+		{"100", "USD", "EUR", "2024-07-01", "80", nil},
+		{"100", "EUR", "USD", "2024-07-01", "125", nil},
+		{"100", "EUR", "JPY", "2024-07-01", "18750", nil},
+		{"100", "USD", "EUR", "2024-03-01", "90", nil},
+		{"100", "USD", "EUR", "2024-06-01", "80", nil},
+		{"0.00625", "USD", "EUR", "2024-07-01", "0.01", nil},
+		{"-0.00625", "USD", "EUR", "2024-07-01", "-0.01", nil},
+		{"0.00624", "USD", "EUR", "2024-07-01", "0", nil},
+		{"1", "JPY", "USD", "2024-07-01", "0.01", nil},
+		{"1.2345", "USD", "BHD", "2024-07-01", "0.464", nil},
+		{"3.33", "USD", "JPY", "2024-07-01", "500", nil},
+		{"10.005", "EUR", "EUR", "2024-07-01", "10.005", nil},
+		{"10", "GBP", "GBP", "2024-07-01", "10", nil},
+		{"100", "USD", "EUR", "2023-12-31", "", errors.ExchangeRateIsNotExists()},
+		{"100", "USD", "GBP", "2024-07-01", "", errors.ExchangeRateIsNotExists()},
+		{"100", "GBP", "USD", "2024-07-01", "", errors.ExchangeRateIsNotExists()},
+	} {
+		got, err := s.convert(decimal.RequireFromString(tt.amount), tt.from, tt.to, date(t, tt.at))
+		if tt.err != nil {
+			if !errs.Is(err, tt.err) {
+				t.Errorf("%s %s to %s: got %v, want %v", tt.amount, tt.from, tt.to, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || !got.Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("%s %s to %s on %s: got %s, %v, want %s", tt.amount, tt.from, tt.to, tt.at, got, err, tt.want)
+		}
+	}
+}
+
+func TestRateLookup(t *testing.T) {
+	s := newRatesServ(t)
+	for _, tt := range []struct {
+		currency, at string
+		want         string
+	}{
+		{"USD", "2000-01-01", "1"},
+		{"EUR", "2024-01-01", "0.9"},
+		{"EUR", "2024-05-31", "0.9"},
+		{"EUR", "2024-06-01", "0.8"},
+		{"EUR", "2030-01-01", "0.8"},
+		{"EUR", "2023-12-31", ""},
+		{"GBP", "2024-07-01", ""},
+	} {
+		got, err := s.rate(tt.currency, date(t, tt.at))
+		if tt.want == "" {
+			if !errs.Is(err, errors.ExchangeRateIsNotExists()) {
+				t.Errorf("%s on %s: got %s, %v", tt.currency, tt.at, got, err)
+			}
+			continue
+		}
+		if err != nil || !got.Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("%s on %s: got %s, %v, want %s", tt.currency, tt.at, got, err, tt.want)
+		}
+	}
+}
+
+func TestSetExchangeRate(t *testing.T) {
+	s := newRatesServ(t)
+	for _, r := range []internal.ExchangeRate{
+		{Currency: "USD", Rate: decimal.NewFromInt(1)},
+		{Currency: "XXX", Rate: decimal.NewFromInt(1)},
+		{Currency: "EUR", Rate: decimal.Zero},
+		{Currency: "EUR", Rate: decimal.NewFromInt(-1)},
+	} {
+		r := r
+		if err := s.setExchangeRate(&r); !errs.Is(err, errors.BadRequest()) {
+			t.Errorf("%s %s: got %v", r.Currency, r.Rate, err)
+		}
+	}
+	replace := internal.ExchangeRate{Currency: "EUR", Rate: decimal.RequireFromString("0.85"),
+		EffectiveDate: date(t, "2024-06-01")}
+	if err := s.setExchangeRate(&replace); err != nil {
+		t.Fatal(err)
+	}
+	rates, err := s.GetExchangeRates(testContext(), "EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rates) != 2 || !rates[0].Rate.Equal(replace.Rate) || rates[0].ID != replace.ID {
+		t.Fatalf("rates = %v", rates)
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/google/uuid"
+)
+
+func (t Serv) CreateDepartment(ctx context.Context, d *internal.Department) error {
+	err := logOperation(ctx, "CreateDepartment")
+	if err != nil {
+		return err
+	}
+	err = authorize(ctx, auth.PermDepartmentsWrite)
+	if err != nil {
+		return err
+	}
+	d.ID = uuid.Nil
+	err = t.checkDepartmentName(d)
+	if err != nil {
+		return err
+	}
+	d.ID = uuid.New()
+	t.repo.AddDepartment(d)
+	return nil
+}
+
+func (t Serv) GetDepartments(ctx context.Context, limit, offset int) ([]internal.Department, error) {
+	if limit > 100 {
+		return nil, errors.BadRequest()
+	}
+	err := logOperation(ctx, "GetDepartments")
+	if err != nil {
+		return nil, err
+	}
+	err = authorize(ctx, auth.PermDepartmentsRead)
+	if err != nil {
+		return nil, err
+	}
+	m := t.repo.GetDepartments()
+	answer := make([]internal.Department, 0)
+	if len(m) == 0 && offset == 1 && limit == 1 {
+		return answer, nil
+	}
+	departments := make([]internal.Department, 0, len(m))
+	for _, value := range m {
+		departments = append(departments, value)
+	}
+	sort.Slice(departments, func(i, j int) bool {
+		if departments[i].Name != departments[j].Name {
+			return departments[i].Name < departments[j].Name
+		}
+		return departments[i].ID.String() < departments[j].ID.String()
+	})
+	offset--
+	if float64(len(departments))/float64(limit) <= float64(offset) || limit < 1 || offset < 0 {
+		return nil, errors.NotFound()
+	}
+	for i := limit * offset; i < limit*offset+limit && i < len(departments); i++ {
+		answer = append(answer, departments[i])
+	}
+	return answer, nil
+}
+
+func (t Serv) GetDepartment(ctx context.Context, id string) (internal.Department, error) {
+	err := logOperation(ctx, "GetDepartment")
+	if err != nil {
+		return internal.Department{}, err
+	}
+	err = authorize(ctx, auth.PermDepartmentsRead)
+	if err != nil {
+		return internal.Department{}, err
+	}
+	d, ok := t.repo.GetDepartments()[idKey(id)]
+	if !ok {
+		return internal.Department{}, errors.NotFound()
+	}
+	return d, nil
+}
+
+func (t Serv) UpdateDepartment(ctx context.Context, d *internal.Department) error {
+	err := logOperation(ctx, "UpdateDepartment")
+	if err != nil {
+		return err
+	}
+	err = authorize(ctx, auth.PermDepartmentsWrite)
+	if err != nil {
+		return err
+	}
+	if d.ID == uuid.Nil {
+		return errors.BadRequest()
+	}
+	err = t.checkDepartmentName(d)
+	if err != nil {
+		return err
+	}
+	return t.repo.UpdateDepartment(d)
+}
+
+func (t Serv) checkDepartmentName(d *internal.Department) error {
+	d.Name = strings.TrimSpace(d.Name)
+	if d.Name == "" {
+		return errors.BadRequest()
+	}
+	for _, value := range t.repo.GetDepartments() {
+		if value.Name == d.Name && value.ID != d.ID {
+			return errors.DepartmentIsExists()
+		}
+	}
+	return nil
+}
+
+func (t Serv) DeleteDepartment(ctx context.Context, id string) error {
+	err := logOperation(ctx, "DeleteDepartment")
+	if err != nil {
+		return err
+	}
+	err = authorize(ctx, auth.PermDepartmentsWrite)
+	if err != nil {
+		return err
+	}
+	id = idKey(id)
+	if _, ok := t.repo.GetDepartments()[id]; !ok {
+		return errors.NotFound()
+	}
+	for _, p := range t.repo.GetPositions() {
+		if p.DepartmentID != nil && p.DepartmentID.String() == id {
+			return errors.DepartmentIsNotEmpty()
+		}
+	}
+	return t.repo.DeleteDepartment(id)
+}
+
+func (t Serv) GetDepartmentEmployees(ctx context.Context, id string) ([]internal.Employee, error) {
+	err := logOperation(ctx, "GetDepartmentEmployees")
+	if err != nil {
+		return nil, err
+	}
+	err = authorize(ctx, auth.PermDepartmentsRead, auth.PermEmployeesRead)
+	if err != nil {
+		return nil, err
+	}
+	id = idKey(id)
+	if _, ok := t.repo.GetDepartments()[id]; !ok {
+		return nil, errors.NotFound()
+	}
+	positions := make(map[uuid.UUID]bool)
+	for _, p := range t.repo.GetPositions() {
+		if p.DepartmentID != nil && p.DepartmentID.String() == id {
+			positions[p.ID] = true
+		}
+	}
+	answer := make([]internal.Employee, 0)
+	for _, e := range t.repo.GetEmployees() {
+		if positions[e.PositionID] {
+			answer = append(answer, e)
+		}
+	}
+	sortEmployees(answer)
+	return answer, nil
+}
+
+func (t Serv) checkDepartment(id *uuid.UUID) error {
+	if id == nil {
+		return nil
+	}
+	if _, ok := t.repo.GetDepartments()[id.String()]; !ok {
+		return errors.DepartmentIsNotExists()
+	}
+	return nil
+}
+
+func idKey(id string) string {
+	uID, err := uuid.Parse(id)
+	if err != nil {
+		return id
+	}
+	return uID.String()
+}
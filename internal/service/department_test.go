@@ -0,0 +1,31 @@
+package service
+
+import (
+	errs "errors"
+	"testing"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+)
+
+func TestDepartmentNames(t *testing.T) {
+	s := newTestServ()
+	ctx := testContext()
+	if err := s.CreateDepartment(ctx, &internal.Department{Name: "   "}); !errs.Is(err, errors.BadRequest()) {
+		t.Fatalf("blank name: got %v", err)
+	}
+	d := internal.Department{Name: "  Sales "}
+	if err := s.CreateDepartment(ctx, &d); err != nil {
+		t.Fatal(err)
+	}
+	if d.Name != "Sales" {
+		t.Fatalf("name = %q, want it trimmed", d.Name)
+	}
+	if err := s.CreateDepartment(ctx, &internal.Department{Name: "Sales\t"}); !errs.Is(err, errors.DepartmentIsExists()) {
+		t.Fatalf("duplicate after trimming: got %v", err)
+	}
+	d.Name = "\n"
+	if err := s.UpdateDepartment(ctx, &d); !errs.Is(err, errors.BadRequest()) {
+		t.Fatalf("blank name on update: got %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+)
+
+func (t Serv) ExpandEmployees(ctx context.Context, employees []internal.Employee) ([]internal.EmployeeWithPosition, error) {
+	err := logOperation(ctx, "ExpandEmployees")
+	if err != nil {
+		return nil, err
+	}
+	err = authorize(ctx, auth.PermEmployeesRead, auth.PermPositionsRead)
+	if err != nil {
+		return nil, err
+	}
+	positions := t.repo.GetPositions()
+	answer := make([]internal.EmployeeWithPosition, 0, len(employees))
+	for _, e := range employees {
+		expanded := internal.EmployeeWithPosition{Employee: e}
+		if p, ok := positions[e.PositionID.String()]; ok {
+			expanded.Position = &p
+		}
+		answer = append(answer, expanded)
+	}
+	return answer, nil
+}
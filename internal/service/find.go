@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+)
+
+func stringSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+func (t Serv) FindEmployees(ctx context.Context, filter internal.EmployeeFilter) ([]internal.Employee, error) {
+	err := logOperation(ctx, "FindEmployees")
+	if err != nil {
+		return nil, err
+	}
+	err = authorize(ctx, auth.PermEmployeesRead)
+	if err != nil {
+		return nil, err
+	}
+	ids := stringSet(filter.IDs)
+	positionIDs := stringSet(filter.PositionIDs)
+	answer := make([]internal.Employee, 0)
+	for _, value := range t.repo.GetEmployees() {
+		if ids != nil && !ids[value.ID.String()] ||
+			positionIDs != nil && !positionIDs[value.PositionID.String()] ||
+			filter.FirstName != "" && value.FirstName != filter.FirstName ||
+			filter.LastName != "" && value.LastName != filter.LastName ||
+			filter.Status != "" && employeeStatus(value) != filter.Status ||
+			!attributesMatch(value.Attributes, filter.Attributes) {
+			continue
+		}
+		answer = append(answer, value)
+	}
+	sortEmployees(answer)
+	return answer, nil
+}
+
+func (t Serv) FindPositions(ctx context.Context, filter internal.PositionFilter) ([]internal.Position, error) {
+	err := logOperation(ctx, "FindPositions")
+	if err != nil {
+		return nil, err
+	}
+	err = authorize(ctx, auth.PermPositionsRead)
+	if err != nil {
+		return nil, err
+	}
+	ids := stringSet(filter.IDs)
+	answer := make([]internal.Position, 0)
+	for _, value := range t.repo.GetPositions() {
+		if ids != nil && !ids[value.ID.String()] ||
+			filter.Name != "" && value.Name != filter.Name {
+			continue
+		}
+		answer = append(answer, value)
+	}
+	sortPositions(answer)
+	return answer, nil
+}
+
+func (t Serv) FindDepartments(ctx context.Context, filter internal.DepartmentFilter) ([]internal.Department, error) {
+	err := logOperation(ctx, "FindDepartments")
+	if err != nil {
+		return nil, err
+	}
+	err = authorize(ctx, auth.PermDepartmentsRead)
+	if err != nil {
+		return nil, err
+	}
+	ids := stringSet(filter.IDs)
+	answer := make([]internal.Department, 0)
+	for _, value := range t.repo.GetDepartments() {
+		if ids != nil && !ids[value.ID.String()] {
+			continue
+		}
+		answer = append(answer, value)
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		if answer[i].Name != answer[j].Name {
+			return answer[i].Name < answer[j].Name
+		}
+		return answer[i].ID.String() < answer[j].ID.String()
+	})
+	return answer, nil
+}
+
+func sortPositions(positions []internal.Position) {
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Name != positions[j].Name {
+			return positions[i].Name < positions[j].Name
+		}
+		return positions[i].ID.String() < positions[j].ID.String()
+	})
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/VTerenya/employees/internal/report"
+)
+
+const (
+	maxReportPeriods    = 240
+	defaultReportMonths = 12
+)
+
+// spanCache holds the employment spans replayed from lifecycle events and
+// is rebuilt only after the employees or their history change.
+type spanCache struct {
+	mu      sync.Mutex
+	built   bool
+	version uint64
+	spans   []report.Span
+}
+
+func (t Serv) employmentSpans() []report.Span {
+	version := t.repo.EmployeesVersion()
+	t.spans.mu.Lock()
+	defer t.spans.mu.Unlock()
+	if !t.spans.built || t.spans.version != version {
+		records := t.repo.GetDeletedEmployees()
+		for id, e := range t.repo.GetEmployees() {
+			records[id] = e
+		}
+		t.spans.spans = report.BuildSpans(records, t.repo.GetAllEvents())
+		t.spans.built, t.spans.version = true, version
+	}
+	return t.spans.spans
+}
+
+// reportPositions names positions that were deleted since, too.
+func (t Serv) reportPositions() map[string]internal.Position {
+	positions := t.repo.GetDeletedPositions()
+	for id, p := range t.repo.GetPositions() {
+		positions[id] = p
+	}
+	return positions
+}
+
+func (t Serv) GetHeadcountReport(ctx context.Context, q internal.ReportRange) (internal.HeadcountReport, error) {
+	err := logOperation(ctx, "GetHeadcountReport")
+	if err != nil {
+		return internal.HeadcountReport{}, err
+	}
+	err = authorize(ctx, auth.PermEmployeesRead, auth.PermPositionsRead)
+	if err != nil {
+		return internal.HeadcountReport{}, err
+	}
+	err = checkReportRange(&q)
+	if err != nil {
+		return internal.HeadcountReport{}, err
+	}
+	return report.Headcount(t.employmentSpans(), t.reportPositions(), q), nil
+}
+
+func (t Serv) GetTurnoverReport(ctx context.Context, q internal.ReportRange) (internal.TurnoverReport, error) {
+	err := logOperation(ctx, "GetTurnoverReport")
+	if err != nil {
+		return internal.TurnoverReport{}, err
+	}
+	err = authorize(ctx, auth.PermEmployeesRead, auth.PermPositionsRead)
+	if err != nil {
+		return internal.TurnoverReport{}, err
+	}
+	err = checkReportRange(&q)
+	if err != nil {
+		return internal.TurnoverReport{}, err
+	}
+	return report.Turnover(t.employmentSpans(), t.reportPositions(), q), nil
+}
+
+// checkReportRange defaults to the twelve calendar months ending with To,
+// which itself defaults to today.
+func checkReportRange(q *internal.ReportRange) error {
+	if q.Interval == "" {
+		q.Interval = internal.IntervalMonth
+	}
+	if !internal.ValidInterval(q.Interval) {
+		return errors.BadRequest()
+	}
+	if q.To.IsZero() {
+		q.To = internal.Today()
+	}
+	if q.From.IsZero() {
+		q.From = internal.NewDate(time.Date(q.To.Year(), q.To.Month()-defaultReportMonths+1, 1, 0, 0, 0, 0, time.UTC))
+	}
+	if q.From.After(q.To.Time) || len(report.Periods(*q)) > maxReportPeriods {
+		return errors.BadRequest()
+	}
+	return nil
+}
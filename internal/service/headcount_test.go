@@ -0,0 +1,52 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/VTerenya/employees/internal"
+)
+
+func TestHeadcountKeepsDeletedEmployees(t *testing.T) {
+	s := newTestServ()
+	ctx := testContext()
+	position := addPosition(t, s, "dev")
+	hired, _ := internal.ParseDate("2024-01-10")
+	left, _ := internal.ParseDate("2024-03-20")
+	e := internal.Employee{FirstName: "ann", LastName: "ann", PositionID: position.ID}
+	if err := s.HireEmployee(ctx, &e, hired); err != nil {
+		t.Fatal(err)
+	}
+	_, err := s.TerminateEmployee(ctx, e.ID.String(), internal.LifecycleChange{EffectiveDate: left, Reason: "left"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	from, _ := internal.ParseDate("2024-01-01")
+	to, _ := internal.ParseDate("2024-04-30")
+	q := internal.ReportRange{From: from, To: to, Interval: internal.IntervalMonth}
+	before, err := s.GetHeadcountReport(ctx, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteEmployee(ctx, e.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeletePosition(ctx, position.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+	after, err := s.GetHeadcountReport(ctx, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, p := range after.Periods {
+		b := before.Periods[i]
+		if p.Headcount != b.Headcount || p.Hires != b.Hires || p.Terminations != b.Terminations {
+			t.Fatalf("period %s changed after the delete: %+v, was %+v", p.Start, p, b)
+		}
+	}
+	if p := after.Periods[0]; p.Headcount != 1 || p.Hires != 1 || p.Positions[0].PositionName != "dev" {
+		t.Fatalf("january = %+v", p)
+	}
+	if p := after.Periods[2]; p.Headcount != 0 || p.Terminations != 1 {
+		t.Fatalf("march = %+v", p)
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/google/uuid"
+)
+
+func (t Serv) checkManager(e *internal.Employee) error {
+	if e.ManagerID == nil {
+		return nil
+	}
+	if *e.ManagerID == e.ID {
+		return errors.ManagerCycle()
+	}
+	employees := t.repo.GetEmployees()
+	if manager, ok := employees[e.ManagerID.String()]; !ok || employeeStatus(manager) == internal.StatusTerminated {
+		return errors.ManagerIsNotExists()
+	}
+	if e.ID == uuid.Nil {
+		return nil
+	}
+	id := *e.ManagerID
+	for steps := 0; steps <= len(employees); steps++ {
+		manager, ok := employees[id.String()]
+		if !ok || manager.ManagerID == nil {
+			return nil
+		}
+		if *manager.ManagerID == e.ID {
+			return errors.ManagerCycle()
+		}
+		id = *manager.ManagerID
+	}
+	return errors.ManagerCycle()
+}
+
+// activeEmployees leaves out terminated employees, who keep their records
+// but no longer appear in the reporting structure.
+func activeEmployees(employees map[string]internal.Employee) map[string]internal.Employee {
+	answer := make(map[string]internal.Employee, len(employees))
+	for key, e := range employees {
+		if employeeStatus(e) != internal.StatusTerminated {
+			answer[key] = e
+		}
+	}
+	return answer
+}
+
+func reportsIndex(employees map[string]internal.Employee) map[uuid.UUID][]internal.Employee {
+	index := make(map[uuid.UUID][]internal.Employee)
+	for _, e := range employees {
+		if e.ManagerID != nil {
+			index[*e.ManagerID] = append(index[*e.ManagerID], e)
+		}
+	}
+	return index
+}
+
+func sortEmployees(employees []internal.Employee) {
+	sort.Slice(employees, func(i, j int) bool {
+		if employees[i].LastName != employees[j].LastName {
+			return employees[i].LastName < employees[j].LastName
+		}
+		if employees[i].FirstName != employees[j].FirstName {
+			return employees[i].FirstName < employees[j].FirstName
+		}
+		return employees[i].ID.String() < employees[j].ID.String()
+	})
+}
+
+func (t Serv) hierarchyRoot(ctx context.Context, operation, id string) (internal.Employee, map[string]internal.Employee, error) {
+	err := logOperation(ctx, operation)
+	if err != nil {
+		return internal.Employee{}, nil, err
+	}
+	err = authorize(ctx, auth.PermEmployeesRead)
+	if err != nil {
+		return internal.Employee{}, nil, err
+	}
+	uID, err := uuid.Parse(id)
+	if err != nil {
+		return internal.Employee{}, nil, errors.NotFound()
+	}
+	employees := t.repo.GetEmployees()
+	e, ok := employees[uID.String()]
+	if !ok {
+		return internal.Employee{}, nil, errors.NotFound()
+	}
+	return e, activeEmployees(employees), nil
+}
+
+func (t Serv) GetDirectReports(ctx context.Context, id string) ([]internal.Employee, error) {
+	e, employees, err := t.hierarchyRoot(ctx, "GetDirectReports", id)
+	if err != nil {
+		return nil, err
+	}
+	answer := make([]internal.Employee, 0)
+	for _, value := range employees {
+		if value.ManagerID != nil && *value.ManagerID == e.ID {
+			answer = append(answer, value)
+		}
+	}
+	sortEmployees(answer)
+	return answer, nil
+}
+
+func (t Serv) GetManagementChain(ctx context.Context, id string) ([]internal.Employee, error) {
+	e, employees, err := t.hierarchyRoot(ctx, "GetManagementChain", id)
+	if err != nil {
+		return nil, err
+	}
+	answer := make([]internal.Employee, 0)
+	seen := map[uuid.UUID]bool{e.ID: true}
+	for e.ManagerID != nil && !seen[*e.ManagerID] {
+		manager, ok := employees[e.ManagerID.String()]
+		if !ok {
+			break
+		}
+		seen[manager.ID] = true
+		answer = append(answer, manager)
+		e = manager
+	}
+	return answer, nil
+}
+
+func (t Serv) GetReportingSubtree(ctx context.Context, id string) ([]internal.Employee, error) {
+	e, employees, err := t.hierarchyRoot(ctx, "GetReportingSubtree", id)
+	if err != nil {
+		return nil, err
+	}
+	index := reportsIndex(employees)
+	answer := make([]internal.Employee, 0)
+	seen := map[uuid.UUID]bool{e.ID: true}
+	queue := []uuid.UUID{e.ID}
+	for len(queue) > 0 {
+		reports := index[queue[0]]
+		queue = queue[1:]
+		sortEmployees(reports)
+		for _, report := range reports {
+			if seen[report.ID] {
+				continue
+			}
+			seen[report.ID] = true
+			answer = append(answer, report)
+			queue = append(queue, report.ID)
+		}
+	}
+	return answer, nil
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/google/uuid"
+)
+
+func employeeStatus(e internal.Employee) string {
+	if e.Status == "" {
+		return internal.StatusActive
+	}
+	return e.Status
+}
+
+// effectiveDate rejects dates after today: a change is applied to the record
+// as soon as it is recorded, so a future date would take effect early.
+func effectiveDate(date internal.Date, events []internal.LifecycleEvent) (internal.Date, error) {
+	today := internal.Today()
+	if date.IsZero() {
+		date = today
+	}
+	if date.After(today.Time) {
+		return internal.Date{}, errors.BadRequest()
+	}
+	for _, event := range events {
+		if date.Before(event.EffectiveDate.Time) {
+			return internal.Date{}, errors.BadRequest()
+		}
+	}
+	return date, nil
+}
+
+func (t Serv) HireEmployee(ctx context.Context, e *internal.Employee, date internal.Date) error {
+	if date.IsZero() {
+		date = internal.Today()
+	}
+	e.HireDate = &date
+	err := t.CreateEmployee(ctx, e)
+	if err != nil {
+		return err
+	}
+	positionID := e.PositionID
+	t.repo.AddEvent(&internal.LifecycleEvent{
+		ID:            uuid.New(),
+		EmployeeID:    e.ID,
+		Type:          internal.EventHire,
+		EffectiveDate: date,
+		ToPositionID:  &positionID,
+		RecordedAt:    time.Now().UTC(),
+	})
+	return nil
+}
+
+func (t Serv) TransferEmployee(ctx context.Context, id string, c internal.LifecycleChange) (internal.Employee, error) {
+	if c.PositionID == nil {
+		return internal.Employee{}, errors.BadRequest()
+	}
+	return t.changeStatus(ctx, "TransferEmployee", id, internal.EventTransfer, c,
+		[]string{internal.StatusActive, internal.StatusOnLeave}, "")
+}
+
+func (t Serv) StartLeave(ctx context.Context, id string, c internal.LifecycleChange) (internal.Employee, error) {
+	return t.changeStatus(ctx, "StartLeave", id, internal.EventLeave, c,
+		[]string{internal.StatusActive}, internal.StatusOnLeave)
+}
+
+func (t Serv) ReturnFromLeave(ctx context.Context, id string, c internal.LifecycleChange) (internal.Employee, error) {
+	return t.changeStatus(ctx, "ReturnFromLeave", id, internal.EventReturn, c,
+		[]string{internal.StatusOnLeave}, internal.StatusActive)
+}
+
+func (t Serv) TerminateEmployee(ctx context.Context, id string, c internal.LifecycleChange) (internal.Employee, error) {
+	if c.Reason == "" {
+		return internal.Employee{}, errors.BadRequest()
+	}
+	e, err := t.changeStatus(ctx, "TerminateEmployee", id, internal.EventTerminate, c,
+		[]string{internal.StatusActive, internal.StatusOnLeave}, internal.StatusTerminated)
+	if err != nil {
+		return internal.Employee{}, err
+	}
+	return e, t.reassignReports(e)
+}
+
+// reassignReports moves the direct reports of a departing employee up to
+// that employee's own manager, or to the top of the chart.
+func (t Serv) reassignReports(e internal.Employee) error {
+	for _, report := range t.repo.GetEmployees() {
+		if report.ManagerID == nil || *report.ManagerID != e.ID {
+			continue
+		}
+		report.ManagerID = nil
+		if e.ManagerID != nil {
+			manager := *e.ManagerID
+			report.ManagerID = &manager
+		}
+		err := t.repo.UpdateEmployee(&report)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t Serv) RehireEmployee(ctx context.Context, id string, c internal.LifecycleChange) (internal.Employee, error) {
+	return t.changeStatus(ctx, "RehireEmployee", id, internal.EventRehire, c,
+		[]string{internal.StatusTerminated}, internal.StatusActive)
+}
+
+func (t Serv) changeStatus(ctx context.Context, operation, id, eventType string, c internal.LifecycleChange,
+	from []string, to string) (internal.Employee, error) {
+	err := logOperation(ctx, operation)
+	if err != nil {
+		return internal.Employee{}, err
+	}
+	err = authorize(ctx, auth.PermEmployeesWrite)
+	if err != nil {
+		return internal.Employee{}, err
+	}
+	uID, err := uuid.Parse(id)
+	if err != nil {
+		return internal.Employee{}, errors.NotFound()
+	}
+	e, ok := t.repo.GetEmployees()[uID.String()]
+	if !ok {
+		return internal.Employee{}, errors.NotFound()
+	}
+	allowed := false
+	for _, status := range from {
+		if employeeStatus(e) == status {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return internal.Employee{}, errors.InvalidTransition()
+	}
+	date, err := effectiveDate(c.EffectiveDate, t.repo.GetEvents(e.ID.String()))
+	if err != nil {
+		return internal.Employee{}, err
+	}
+	event := internal.LifecycleEvent{
+		ID:            uuid.New(),
+		EmployeeID:    e.ID,
+		Type:          eventType,
+		EffectiveDate: date,
+		Reason:        c.Reason,
+		RecordedAt:    time.Now().UTC(),
+	}
+	if c.PositionID != nil && *c.PositionID != e.PositionID {
+		if _, ok := t.repo.GetPositions()[c.PositionID.String()]; !ok {
+			return internal.Employee{}, errors.PositionIsNotExists()
+		}
+		fromPosition, toPosition := e.PositionID, *c.PositionID
+		event.FromPositionID, event.ToPositionID = &fromPosition, &toPosition
+		e.PositionID = toPosition
+	} else if eventType == internal.EventTransfer {
+		return internal.Employee{}, errors.BadRequest()
+	}
+	if to != "" {
+		e.Status = to
+	}
+	err = t.repo.UpdateEmployee(&e)
+	if err != nil {
+		return internal.Employee{}, err
+	}
+	t.repo.AddEvent(&event)
+	return e, nil
+}
+
+// RestoreEmployee stores an exported employee under its own id with its
+// status and lifecycle events, so that moving data between servers keeps
+// terminated employees terminated and their history intact.
+func (t Serv) RestoreEmployee(ctx context.Context, r *internal.EmployeeRecord) error {
+	err := logOperation(ctx, "RestoreEmployee")
+	if err != nil {
+		return err
+	}
+	err = authorize(ctx, auth.PermEmployeesWrite)
+	if err != nil {
+		return err
+	}
+	e := &r.Employee
+	if e.ID == uuid.Nil {
+		return errors.BadRequest()
+	}
+	if _, ok := t.repo.GetEmployees()[e.ID.String()]; ok {
+		return errors.EmployeeIsExists()
+	}
+	if e.Status == "" {
+		e.Status = internal.StatusActive
+	}
+	if !internal.ValidStatus(e.Status) {
+		return errors.BadRequest()
+	}
+	now := time.Now().UTC()
+	for i := range r.Events {
+		event := &r.Events[i]
+		if event.EmployeeID != e.ID || !internal.ValidEventType(event.Type) || event.EffectiveDate.IsZero() {
+			return errors.BadRequest()
+		}
+		if event.ID == uuid.Nil {
+			event.ID = uuid.New()
+		}
+		if event.RecordedAt.IsZero() {
+			event.RecordedAt = now
+		}
+	}
+	err = t.checkNewEmployee(e)
+	if err != nil {
+		return err
+	}
+	t.repo.AddEmployee(e)
+	for i := range r.Events {
+		t.repo.AddEvent(&r.Events[i])
+	}
+	return nil
+}
+
+func (t Serv) GetEmployeeEvents(ctx context.Context, id string) ([]internal.LifecycleEvent, error) {
+	e, _, err := t.hierarchyRoot(ctx, "GetEmployeeEvents", id)
+	if err != nil {
+		return nil, err
+	}
+	return t.repo.GetEvents(e.ID.String()), nil
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	errs "errors"
+	"testing"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func addPosition(t *testing.T, s *Serv, name string) internal.Position {
+	t.Helper()
+	p := internal.Position{Name: name, Salary: decimal.NewFromInt(100)}
+	if err := s.CreatePosition(testContext(), &p); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func addEmployee(t *testing.T, s *Serv, name string, position uuid.UUID, manager *uuid.UUID) internal.Employee {
+	t.Helper()
+	e := internal.Employee{FirstName: name, LastName: name, PositionID: position, ManagerID: manager}
+	if err := s.CreateEmployee(testContext(), &e); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func terminate(t *testing.T, s *Serv, e internal.Employee) {
+	t.Helper()
+	if _, err := s.TerminateEmployee(testContext(), e.ID.String(), internal.LifecycleChange{Reason: "left"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteEmployeeRequiresTermination(t *testing.T) {
+	s := newTestServ()
+	e := addEmployee(t, s, "ann", addPosition(t, s, "dev").ID, nil)
+	if err := s.DeleteEmployee(testContext(), e.ID.String()); !errs.Is(err, errors.EmployeeIsNotTerminated()) {
+		t.Fatalf("deleting an active employee: got %v", err)
+	}
+	terminate(t, s, e)
+	if err := s.DeleteEmployee(testContext(), e.ID.String()); err != nil {
+		t.Fatalf("deleting a terminated employee: %v", err)
+	}
+}
+
+func TestUpdateEmployeeKeepsPosition(t *testing.T) {
+	s := newTestServ()
+	e := addEmployee(t, s, "ann", addPosition(t, s, "dev").ID, nil)
+	e.PositionID = addPosition(t, s, "lead").ID
+	if err := s.UpdateEmployee(testContext(), &e); !errs.Is(err, errors.PositionNeedsTransfer()) {
+		t.Fatalf("changing the position: got %v", err)
+	}
+}
+
+func TestTerminatedEmployeesLeaveTheHierarchy(t *testing.T) {
+	s := newTestServ()
+	position := addPosition(t, s, "dev").ID
+	boss := addEmployee(t, s, "boss", position, nil)
+	lead := addEmployee(t, s, "lead", position, &boss.ID)
+	dev := addEmployee(t, s, "dev", position, &lead.ID)
+	terminate(t, s, lead)
+
+	reports, err := s.GetDirectReports(testContext(), boss.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 1 || reports[0].ID != dev.ID {
+		t.Fatalf("reports of boss = %v, want the dev moved up", reports)
+	}
+	subtree, err := s.GetReportingSubtree(testContext(), boss.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subtree) != 1 {
+		t.Fatalf("subtree of boss has %d employees, want 1", len(subtree))
+	}
+	chart, err := s.OrgChart(testContext(), internal.OrgChartQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chart) != 1 || chart[0].ID != boss.ID || len(chart[0].Reports) != 1 || chart[0].Reports[0].ID != dev.ID {
+		t.Fatalf("org chart does not skip the terminated lead: %+v", chart)
+	}
+	dev.ManagerID = &lead.ID
+	if err := s.UpdateEmployee(testContext(), &dev); !errs.Is(err, errors.ManagerIsNotExists()) {
+		t.Fatalf("reporting to a terminated manager: got %v", err)
+	}
+}
+
+func TestLifecycleRejectsFutureDates(t *testing.T) {
+	s := newTestServ()
+	position := addPosition(t, s, "dev").ID
+	boss := addEmployee(t, s, "boss", position, nil)
+	dev := addEmployee(t, s, "dev", position, &boss.ID)
+	tomorrow := internal.NewDate(internal.Today().AddDate(0, 0, 1))
+	_, err := s.TerminateEmployee(testContext(), boss.ID.String(),
+		internal.LifecycleChange{Reason: "left", EffectiveDate: tomorrow})
+	if !errs.Is(err, errors.BadRequest()) {
+		t.Fatalf("terminating tomorrow: got %v", err)
+	}
+	got, err := s.GetEmployee(testContext(), boss.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status == internal.StatusTerminated {
+		t.Fatal("future termination applied today")
+	}
+	reports, err := s.GetDirectReports(testContext(), boss.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 1 || reports[0].ID != dev.ID {
+		t.Fatalf("reports moved by a rejected termination: %v", reports)
+	}
+	_, err = s.TerminateEmployee(testContext(), boss.ID.String(),
+		internal.LifecycleChange{Reason: "left", EffectiveDate: internal.Today()})
+	if err != nil {
+		t.Fatalf("terminating today: %v", err)
+	}
+}
+
+func TestRestoreEmployeeKeepsIDStatusAndHistory(t *testing.T) {
+	s := newTestServ()
+	position := addPosition(t, s, "dev").ID
+	id := uuid.New()
+	hired := internal.NewDate(internal.Today().AddDate(-1, 0, 0))
+	left := internal.NewDate(internal.Today().AddDate(0, -1, 0))
+	rec := internal.EmployeeRecord{
+		Employee: internal.Employee{ID: id, FirstName: "ann", LastName: "ann", PositionID: position,
+			Status: internal.StatusTerminated},
+		Events: []internal.LifecycleEvent{
+			{EmployeeID: id, Type: internal.EventHire, EffectiveDate: hired, ToPositionID: &position},
+			{EmployeeID: id, Type: internal.EventTerminate, EffectiveDate: left, Reason: "left"},
+		},
+	}
+	if err := s.RestoreEmployee(testContext(), &rec); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.GetEmployee(testContext(), id.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != internal.StatusTerminated {
+		t.Fatalf("status = %q", got.Status)
+	}
+	events, err := s.GetEmployeeEvents(testContext(), id.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[1].Type != internal.EventTerminate || !events[1].EffectiveDate.Equal(left.Time) {
+		t.Fatalf("events = %+v", events)
+	}
+
+	again := internal.EmployeeRecord{Employee: internal.Employee{ID: id, FirstName: "bob", LastName: "bob",
+		PositionID: position}}
+	if err := s.RestoreEmployee(testContext(), &again); !errs.Is(err, errors.EmployeeIsExists()) {
+		t.Fatalf("restoring over an existing id: got %v", err)
+	}
+	other := uuid.New()
+	foreign := internal.EmployeeRecord{
+		Employee: internal.Employee{ID: other, FirstName: "bob", LastName: "bob", PositionID: position},
+		Events:   []internal.LifecycleEvent{{EmployeeID: id, Type: internal.EventHire, EffectiveDate: hired}},
+	}
+	if err := s.RestoreEmployee(testContext(), &foreign); !errs.Is(err, errors.BadRequest()) {
+		t.Fatalf("restoring another employee's events: got %v", err)
+	}
+	bad := internal.EmployeeRecord{Employee: internal.Employee{ID: other, FirstName: "bob", LastName: "bob",
+		PositionID: position, Status: "retired"}}
+	if err := s.RestoreEmployee(testContext(), &bad); !errs.Is(err, errors.BadRequest()) {
+		t.Fatalf("restoring an unknown status: got %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/google/uuid"
+)
+
+func (t Serv) OrgChart(ctx context.Context, q internal.OrgChartQuery) ([]*internal.OrgChartNode, error) {
+	err := logOperation(ctx, "OrgChart")
+	if err != nil {
+		return nil, err
+	}
+	err = authorize(ctx, auth.PermEmployeesRead, auth.PermPositionsRead)
+	if err != nil {
+		return nil, err
+	}
+	if q.Depth < 0 || q.EmployeeID != "" && q.DepartmentID != "" {
+		return nil, errors.BadRequest()
+	}
+	positions := t.repo.GetPositions()
+	employees := activeEmployees(t.repo.GetEmployees())
+	if q.DepartmentID != "" {
+		err = authorize(ctx, auth.PermDepartmentsRead)
+		if err != nil {
+			return nil, err
+		}
+		id := idKey(q.DepartmentID)
+		if _, ok := t.repo.GetDepartments()[id]; !ok {
+			return nil, errors.NotFound()
+		}
+		members := make(map[string]internal.Employee)
+		for key, e := range employees {
+			p, ok := positions[e.PositionID.String()]
+			if ok && p.DepartmentID != nil && p.DepartmentID.String() == id {
+				members[key] = e
+			}
+		}
+		employees = members
+	}
+	var roots []internal.Employee
+	if q.EmployeeID != "" {
+		uID, err := uuid.Parse(q.EmployeeID)
+		if err != nil {
+			return nil, errors.NotFound()
+		}
+		e, ok := employees[uID.String()]
+		if !ok {
+			return nil, errors.NotFound()
+		}
+		roots = []internal.Employee{e}
+	} else {
+		for _, e := range employees {
+			if e.ManagerID == nil {
+				roots = append(roots, e)
+				continue
+			}
+			if _, ok := employees[e.ManagerID.String()]; !ok {
+				roots = append(roots, e)
+			}
+		}
+		sortEmployees(roots)
+	}
+	index := reportsIndex(employees)
+	if q.EmployeeID == "" {
+		roots = append(roots, cycleRoots(employees, roots, index)...)
+	}
+	seen := make(map[uuid.UUID]bool)
+	var build func(e internal.Employee, level int) *internal.OrgChartNode
+	build = func(e internal.Employee, level int) *internal.OrgChartNode {
+		seen[e.ID] = true
+		node := &internal.OrgChartNode{ID: e.ID, FirstName: e.FirstName, LastName: e.LastName, PositionID: e.PositionID}
+		if p, ok := positions[e.PositionID.String()]; ok {
+			node.Title = p.Name
+		}
+		if q.Depth > 0 && level >= q.Depth {
+			return node
+		}
+		reports := index[e.ID]
+		sortEmployees(reports)
+		for _, report := range reports {
+			if !seen[report.ID] {
+				node.Reports = append(node.Reports, build(report, level+1))
+			}
+		}
+		return node
+	}
+	answer := make([]*internal.OrgChartNode, 0, len(roots))
+	for _, root := range roots {
+		answer = append(answer, build(root, 1))
+	}
+	return answer, nil
+}
+
+// cycleRoots picks one employee from every group whose managers form a cycle,
+// as no root of the chart reaches them.
+func cycleRoots(employees map[string]internal.Employee, roots []internal.Employee,
+	index map[uuid.UUID][]internal.Employee) []internal.Employee {
+	reached := make(map[uuid.UUID]bool, len(employees))
+	var walk func(id uuid.UUID)
+	walk = func(id uuid.UUID) {
+		if reached[id] {
+			return
+		}
+		reached[id] = true
+		for _, report := range index[id] {
+			walk(report.ID)
+		}
+	}
+	for _, root := range roots {
+		walk(root.ID)
+	}
+	rest := make([]internal.Employee, 0)
+	for _, e := range employees {
+		if !reached[e.ID] {
+			rest = append(rest, e)
+		}
+	}
+	sortEmployees(rest)
+	var answer []internal.Employee
+	for _, e := range rest {
+		if !reached[e.ID] {
+			answer = append(answer, e)
+			walk(e.ID)
+		}
+	}
+	return answer
+}
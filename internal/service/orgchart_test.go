@@ -0,0 +1,111 @@
+package service
+
+import (
+	errs "errors"
+	"testing"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/google/uuid"
+)
+
+func chartNames(nodes []*internal.OrgChartNode) []string {
+	var names []string
+	var walk func(n *internal.OrgChartNode, prefix string)
+	walk = func(n *internal.OrgChartNode, prefix string) {
+		names = append(names, prefix+n.FirstName)
+		for _, r := range n.Reports {
+			walk(r, prefix+n.FirstName+"/")
+		}
+	}
+	for _, n := range nodes {
+		walk(n, "")
+	}
+	return names
+}
+
+func equalNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestOrgChartDepth(t *testing.T) {
+	s := newTestServ()
+	p := addPosition(t, s, "dev").ID
+	ceo := addEmployee(t, s, "ceo", p, nil)
+	cto := addEmployee(t, s, "cto", p, &ceo.ID)
+	addEmployee(t, s, "dev", p, &cto.ID)
+	addEmployee(t, s, "cfo", p, &ceo.ID)
+	for _, tt := range []struct {
+		q    internal.OrgChartQuery
+		want []string
+	}{
+		{internal.OrgChartQuery{}, []string{"ceo", "ceo/cfo", "ceo/cto", "ceo/cto/dev"}},
+		{internal.OrgChartQuery{Depth: 1}, []string{"ceo"}},
+		{internal.OrgChartQuery{Depth: 2}, []string{"ceo", "ceo/cfo", "ceo/cto"}},
+		{internal.OrgChartQuery{EmployeeID: cto.ID.String()}, []string{"cto", "cto/dev"}},
+		{internal.OrgChartQuery{EmployeeID: cto.ID.String(), Depth: 1}, []string{"cto"}},
+	} {
+		nodes, err := s.OrgChart(testContext(), tt.q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := chartNames(nodes); !equalNames(got, tt.want) {
+			t.Errorf("%+v: got %v, want %v", tt.q, got, tt.want)
+		}
+	}
+	if _, err := s.OrgChart(testContext(), internal.OrgChartQuery{Depth: -1}); !errs.Is(err, errors.BadRequest()) {
+		t.Fatalf("negative depth: got %v", err)
+	}
+}
+
+func TestOrgChartMissingRoot(t *testing.T) {
+	s := newTestServ()
+	p := addPosition(t, s, "dev").ID
+	gone := addEmployee(t, s, "gone", p, nil)
+	terminate(t, s, gone)
+	for _, id := range []string{uuid.New().String(), "not-a-uuid", gone.ID.String()} {
+		_, err := s.OrgChart(testContext(), internal.OrgChartQuery{EmployeeID: id})
+		if !errs.Is(err, errors.NotFound()) {
+			t.Errorf("root %s: got %v", id, err)
+		}
+	}
+	_, err := s.OrgChart(testContext(), internal.OrgChartQuery{DepartmentID: uuid.New().String()})
+	if !errs.Is(err, errors.NotFound()) {
+		t.Fatalf("missing department: got %v", err)
+	}
+}
+
+func TestOrgChartCycle(t *testing.T) {
+	s := newTestServ()
+	p := addPosition(t, s, "dev").ID
+	ann := addEmployee(t, s, "ann", p, nil)
+	bob := addEmployee(t, s, "bob", p, &ann.ID)
+	addEmployee(t, s, "cid", p, &bob.ID)
+	// The service refuses cycles, so write one straight to the repository.
+	ann.ManagerID = &bob.ID
+	if err := s.repo.UpdateEmployee(&ann); err != nil {
+		t.Fatal(err)
+	}
+	nodes, err := s.OrgChart(testContext(), internal.OrgChartQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := chartNames(nodes), []string{"ann", "ann/bob", "ann/bob/cid"}; !equalNames(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	nodes, err = s.OrgChart(testContext(), internal.OrgChartQuery{EmployeeID: bob.ID.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := chartNames(nodes), []string{"bob", "bob/ann", "bob/cid"}; !equalNames(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const compaRatioPlaces = 4
+
+func (t Serv) CreatePayGrade(ctx context.Context, g *internal.PayGrade) error {
+	err := logOperation(ctx, "CreatePayGrade")
+	if err != nil {
+		return err
+	}
+	err = authorize(ctx, auth.PermPositionsWrite, auth.PermSalaryWrite)
+	if err != nil {
+		return err
+	}
+	err = t.checkPayGrade(g)
+	if err != nil {
+		return err
+	}
+	g.ID = uuid.New()
+	t.repo.AddPayGrade(g)
+	return nil
+}
+
+func (t Serv) GetPayGrades(ctx context.Context) ([]internal.PayGrade, error) {
+	err := logOperation(ctx, "GetPayGrades")
+	if err != nil {
+		return nil, err
+	}
+	err = authorize(ctx, auth.PermPositionsRead, auth.PermSalaryRead)
+	if err != nil {
+		return nil, err
+	}
+	answer := make([]internal.PayGrade, 0)
+	for _, g := range t.repo.GetPayGrades() {
+		answer = append(answer, g)
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		if !answer[i].Min.Equal(answer[j].Min) {
+			return answer[i].Min.LessThan(answer[j].Min)
+		}
+		return answer[i].Name < answer[j].Name
+	})
+	return answer, nil
+}
+
+func (t Serv) GetPayGrade(ctx context.Context, id string) (internal.PayGrade, error) {
+	err := logOperation(ctx, "GetPayGrade")
+	if err != nil {
+		return internal.PayGrade{}, err
+	}
+	err = authorize(ctx, auth.PermPositionsRead, auth.PermSalaryRead)
+	if err != nil {
+		return internal.PayGrade{}, err
+	}
+	g, ok := t.repo.GetPayGrades()[idKey(id)]
+	if !ok {
+		return internal.PayGrade{}, errors.NotFound()
+	}
+	return g, nil
+}
+
+func (t Serv) UpdatePayGrade(ctx context.Context, g *internal.PayGrade) error {
+	err := logOperation(ctx, "UpdatePayGrade")
+	if err != nil {
+		return err
+	}
+	err = authorize(ctx, auth.PermPositionsWrite, auth.PermSalaryWrite)
+	if err != nil {
+		return err
+	}
+	if g.ID == uuid.Nil {
+		return errors.BadRequest()
+	}
+	err = t.checkPayGrade(g)
+	if err != nil {
+		return err
+	}
+	err = t.checkLinkedPositions(*g)
+	if err != nil {
+		return err
+	}
+	return t.repo.UpdatePayGrade(g)
+}
+
+func (t Serv) DeletePayGrade(ctx context.Context, id string) error {
+	err := logOperation(ctx, "DeletePayGrade")
+	if err != nil {
+		return err
+	}
+	err = authorize(ctx, auth.PermPositionsWrite, auth.PermSalaryWrite)
+	if err != nil {
+		return err
+	}
+	id = idKey(id)
+	if _, ok := t.repo.GetPayGrades()[id]; !ok {
+		return errors.NotFound()
+	}
+	for _, p := range t.repo.GetPositions() {
+		if p.PayGradeID != nil && p.PayGradeID.String() == id {
+			return errors.PayGradeIsNotEmpty()
+		}
+	}
+	return t.repo.DeletePayGrade(id)
+}
+
+func (t Serv) GetCompaRatios(ctx context.Context) ([]internal.CompaRatio, error) {
+	err := logOperation(ctx, "GetCompaRatios")
+	if err != nil {
+		return nil, err
+	}
+	err = authorize(ctx, auth.PermPositionsRead, auth.PermSalaryRead)
+	if err != nil {
+		return nil, err
+	}
+	grades := t.repo.GetPayGrades()
+	answer := make([]internal.CompaRatio, 0)
+	for _, p := range t.repo.GetPositions() {
+		if p.PayGradeID == nil {
+			continue
+		}
+		g, ok := grades[p.PayGradeID.String()]
+		if !ok {
+			continue
+		}
+		ratio, err := t.compaRatio(p, g)
+		if err != nil {
+			return nil, err
+		}
+		answer = append(answer, ratio)
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		if answer[i].PositionName != answer[j].PositionName {
+			return answer[i].PositionName < answer[j].PositionName
+		}
+		return answer[i].PositionID.String() < answer[j].PositionID.String()
+	})
+	return answer, nil
+}
+
+func (t Serv) compaRatio(p internal.Position, g internal.PayGrade) (internal.CompaRatio, error) {
+	salary, err := t.convert(p.Salary, p.Currency, g.Currency, internal.Today())
+	if err != nil {
+		return internal.CompaRatio{}, err
+	}
+	ratio := decimal.Zero
+	if !g.Mid.IsZero() {
+		ratio = salary.DivRound(g.Mid, compaRatioPlaces)
+	}
+	return internal.CompaRatio{
+		PositionID:     p.ID,
+		PositionName:   p.Name,
+		PayGradeID:     g.ID,
+		PayGradeName:   g.Name,
+		Salary:         salary,
+		Min:            g.Min,
+		Mid:            g.Mid,
+		Max:            g.Max,
+		Currency:       g.Currency,
+		CompaRatio:     ratio,
+		InBand:         g.InBand(salary),
+		OverrideReason: p.SalaryOverrideReason,
+	}, nil
+}
+
+func (t Serv) checkPayGrade(g *internal.PayGrade) error {
+	g.Name = strings.TrimSpace(g.Name)
+	err := t.checkCurrency(&g.Currency, t.repo.GetPayGrades()[g.ID.String()].Currency)
+	if err != nil {
+		return err
+	}
+	if g.Name == "" || g.Min.IsNegative() || g.Mid.LessThan(g.Min) || g.Max.LessThan(g.Mid) || !g.Max.IsPositive() {
+		return errors.BadRequest()
+	}
+	for _, value := range t.repo.GetPayGrades() {
+		if value.Name == g.Name && value.ID != g.ID {
+			return errors.PayGradeIsExists()
+		}
+	}
+	return nil
+}
+
+func (t Serv) checkSalaryBand(p *internal.Position) error {
+	p.SalaryOverrideReason = strings.TrimSpace(p.SalaryOverrideReason)
+	if p.PayGradeID == nil {
+		p.SalaryOverrideReason = ""
+		return nil
+	}
+	g, ok := t.repo.GetPayGrades()[p.PayGradeID.String()]
+	if !ok {
+		return errors.PayGradeIsNotExists()
+	}
+	salary, err := t.convert(p.Salary, p.Currency, g.Currency, internal.Today())
+	if err != nil {
+		return err
+	}
+	if g.InBand(salary) {
+		p.SalaryOverrideReason = ""
+		return nil
+	}
+	if p.SalaryOverrideReason == "" {
+		return errors.SalaryOutOfBand()
+	}
+	return nil
+}
+
+// checkLinkedPositions keeps a new band from leaving positions of the grade
+// outside it without an override reason.
+func (t Serv) checkLinkedPositions(g internal.PayGrade) error {
+	for _, p := range t.repo.GetPositions() {
+		if p.PayGradeID == nil || *p.PayGradeID != g.ID || p.SalaryOverrideReason != "" {
+			continue
+		}
+		salary, err := t.convert(p.Salary, p.Currency, g.Currency, internal.Today())
+		if err != nil {
+			return err
+		}
+		if !g.InBand(salary) {
+			return errors.SalaryOutOfBand()
+		}
+	}
+	return nil
+}
+
+func compensationChanged(current, p internal.Position) bool {
+	if !current.Salary.Equal(p.Salary) || current.Currency != p.Currency ||
+		current.SalaryOverrideReason != p.SalaryOverrideReason {
+		return true
+	}
+	if current.PayGradeID == nil || p.PayGradeID == nil {
+		return current.PayGradeID != p.PayGradeID
+	}
+	return *current.PayGradeID != *p.PayGradeID
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	errs "errors"
+	"testing"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/shopspring/decimal"
+)
+
+func TestUpdatePayGradeChecksLinkedPositions(t *testing.T) {
+	s := newTestServ()
+	ctx := testContext()
+	g := internal.PayGrade{
+		Name: "P1", Min: decimal.NewFromInt(50), Mid: decimal.NewFromInt(100), Max: decimal.NewFromInt(150),
+	}
+	if err := s.CreatePayGrade(ctx, &g); err != nil {
+		t.Fatal(err)
+	}
+	p := internal.Position{Name: "dev", Salary: decimal.NewFromInt(60), PayGradeID: &g.ID}
+	if err := s.CreatePosition(ctx, &p); err != nil {
+		t.Fatal(err)
+	}
+	raised := g
+	raised.Min, raised.Mid, raised.Max = decimal.NewFromInt(80), decimal.NewFromInt(120), decimal.NewFromInt(160)
+	if err := s.UpdatePayGrade(ctx, &raised); !errs.Is(err, errors.SalaryOutOfBand()) {
+		t.Fatalf("band leaving a position behind: got %v", err)
+	}
+	if got, _ := s.GetPayGrade(ctx, g.ID.String()); !got.Min.Equal(g.Min) {
+		t.Fatalf("rejected update was stored: min = %s", got.Min)
+	}
+	p.Salary = decimal.NewFromInt(90)
+	if err := s.UpdatePosition(ctx, &p); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdatePayGrade(ctx, &raised); err != nil {
+		t.Fatalf("position inside the new band: %v", err)
+	}
+	lowered := raised
+	lowered.Min, lowered.Mid, lowered.Max = decimal.NewFromInt(10), decimal.NewFromInt(20), decimal.NewFromInt(30)
+	p.Salary, p.SalaryOverrideReason = decimal.NewFromInt(200), "retention"
+	if err := s.UpdatePosition(ctx, &p); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdatePayGrade(ctx, &lowered); err != nil {
+		t.Fatalf("position with an override reason: %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/shopspring/decimal"
+)
+
+func (t Serv) GetPayrollReport(ctx context.Context, currency string) (internal.PayrollReport, error) {
+	err := logOperation(ctx, "GetPayrollReport")
+	if err != nil {
+		return internal.PayrollReport{}, err
+	}
+	err = authorize(ctx, auth.PermEmployeesRead, auth.PermPositionsRead, auth.PermSalaryRead)
+	if err != nil {
+		return internal.PayrollReport{}, err
+	}
+	err = t.checkCurrency(&currency, "")
+	if err != nil {
+		return internal.PayrollReport{}, err
+	}
+	today := internal.Today()
+	positions := t.repo.GetPositions()
+	salaries := make(map[string]decimal.Decimal, len(positions))
+	for id, p := range positions {
+		salaries[id], err = t.convert(p.Salary, p.Currency, currency, today)
+		if err != nil {
+			return internal.PayrollReport{}, err
+		}
+	}
+	all := make([]decimal.Decimal, 0)
+	byPosition := make(map[string][]decimal.Decimal, len(positions))
+	byDepartment := make(map[string][]decimal.Decimal)
+	for id, p := range positions {
+		byPosition[id] = make([]decimal.Decimal, 0)
+		byDepartment[departmentKey(p)] = make([]decimal.Decimal, 0)
+	}
+	for _, e := range t.repo.GetEmployees() {
+		p, ok := positions[e.PositionID.String()]
+		if !ok || employeeStatus(e) == internal.StatusTerminated {
+			continue
+		}
+		salary := salaries[p.ID.String()]
+		all = append(all, salary)
+		byPosition[p.ID.String()] = append(byPosition[p.ID.String()], salary)
+		byDepartment[departmentKey(p)] = append(byDepartment[departmentKey(p)], salary)
+	}
+	report := internal.PayrollReport{
+		Currency:     currency,
+		AsOf:         today,
+		PayrollStats: payrollStats(all, currency),
+		Positions:    make([]internal.PositionPayroll, 0, len(byPosition)),
+		Departments:  make([]internal.DepartmentPayroll, 0, len(byDepartment)),
+	}
+	for id, amounts := range byPosition {
+		p := positions[id]
+		report.Positions = append(report.Positions, internal.PositionPayroll{
+			PositionID:   p.ID,
+			PositionName: p.Name,
+			DepartmentID: p.DepartmentID,
+			PayrollStats: payrollStats(amounts, currency),
+		})
+	}
+	sort.Slice(report.Positions, func(i, j int) bool {
+		if report.Positions[i].PositionName != report.Positions[j].PositionName {
+			return report.Positions[i].PositionName < report.Positions[j].PositionName
+		}
+		return report.Positions[i].PositionID.String() < report.Positions[j].PositionID.String()
+	})
+	departments := t.repo.GetDepartments()
+	canReadDepartments := auth.Can(ctx, auth.PermDepartmentsRead)
+	for id, amounts := range byDepartment {
+		item := internal.DepartmentPayroll{PayrollStats: payrollStats(amounts, currency)}
+		if d, ok := departments[id]; ok {
+			item.DepartmentID = &d.ID
+			if canReadDepartments {
+				item.DepartmentName = d.Name
+			}
+		}
+		report.Departments = append(report.Departments, item)
+	}
+	sort.Slice(report.Departments, func(i, j int) bool {
+		a, b := report.Departments[i], report.Departments[j]
+		if (a.DepartmentID == nil) != (b.DepartmentID == nil) {
+			return b.DepartmentID == nil
+		}
+		if a.DepartmentName != b.DepartmentName {
+			return a.DepartmentName < b.DepartmentName
+		}
+		return a.DepartmentID != nil && a.DepartmentID.String() < b.DepartmentID.String()
+	})
+	return report, nil
+}
+
+func departmentKey(p internal.Position) string {
+	if p.DepartmentID == nil {
+		return ""
+	}
+	return p.DepartmentID.String()
+}
+
+// payrollStats keeps totals exact and rounds the average and median
+// to the currency's minor units, half away from zero.
+func payrollStats(amounts []decimal.Decimal, currency string) internal.PayrollStats {
+	stats := internal.PayrollStats{Headcount: len(amounts)}
+	if len(amounts) == 0 {
+		return stats
+	}
+	sorted := make([]decimal.Decimal, len(amounts))
+	copy(sorted, amounts)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].LessThan(sorted[j])
+	})
+	places := internal.MinorUnits(currency)
+	stats.Total = decimal.Sum(sorted[0], sorted[1:]...)
+	stats.Average = stats.Total.DivRound(decimal.NewFromInt(int64(len(sorted))), places)
+	middle := len(sorted) / 2
+	stats.Median = sorted[middle]
+	if len(sorted)%2 == 0 {
+		stats.Median = sorted[middle-1].Add(sorted[middle]).DivRound(decimal.NewFromInt(2), places)
+	}
+	stats.Min = sorted[0]
+	stats.Max = sorted[len(sorted)-1]
+	return stats
+}
@@ -0,0 +1,24 @@
+package service
+
+import "testing"
+
+func TestPayrollReportListsEmptyPositions(t *testing.T) {
+	s := newTestServ()
+	staffed := addPosition(t, s, "dev")
+	empty := addPosition(t, s, "lead")
+	addEmployee(t, s, "ann", staffed.ID, nil)
+	r, err := s.GetPayrollReport(testContext(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	headcount := make(map[string]int)
+	for _, p := range r.Positions {
+		headcount[p.PositionName] = p.Headcount
+	}
+	if len(r.Positions) != 2 || headcount[staffed.Name] != 1 || headcount[empty.Name] != 0 {
+		t.Fatalf("positions = %+v", r.Positions)
+	}
+	if r.Headcount != 1 {
+		t.Fatalf("total headcount = %d, want 1", r.Headcount)
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/errors"
+)
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9][0-9 ().\-]{4,24}$`) // nolint: gochecknoglobals
+
+func (t Serv) checkProfile(e *internal.Employee) error {
+	e.Email = strings.TrimSpace(e.Email)
+	e.Phone = strings.TrimSpace(e.Phone)
+	e.EmployeeNumber = strings.TrimSpace(e.EmployeeNumber)
+	if e.Email != "" {
+		addr, err := mail.ParseAddress(e.Email)
+		if err != nil || addr.Address != e.Email || addr.Name != "" {
+			return errors.BadRequest()
+		}
+	}
+	if e.Phone != "" && !phonePattern.MatchString(e.Phone) {
+		return errors.BadRequest()
+	}
+	if e.EmploymentType != "" && !internal.ValidEmploymentType(e.EmploymentType) {
+		return errors.BadRequest()
+	}
+	if e.DateOfBirth != nil {
+		if e.DateOfBirth.After(internal.Today().Time) {
+			return errors.BadRequest()
+		}
+		if e.HireDate != nil && !e.DateOfBirth.Before(e.HireDate.Time) {
+			return errors.BadRequest()
+		}
+	}
+	for _, value := range t.repo.GetEmployees() {
+		if value.ID == e.ID {
+			continue
+		}
+		if e.Email != "" && strings.EqualFold(value.Email, e.Email) {
+			return errors.EmailIsExists()
+		}
+		if e.EmployeeNumber != "" && value.EmployeeNumber == e.EmployeeNumber {
+			return errors.EmployeeNumberIsExists()
+		}
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"time"
+
 	"github.com/VTerenya/employees/internal"
 )
 
@@ -13,4 +15,31 @@ type Repository interface {
 	DeleteEmployee(id string) error
 	UpdatePosition(p *internal.Position) error
 	UpdateEmployee(e *internal.Employee) error
+	GetDepartments() map[string]internal.Department
+	AddDepartment(d *internal.Department)
+	DeleteDepartment(id string) error
+	UpdateDepartment(d *internal.Department) error
+	GetPayGrades() map[string]internal.PayGrade
+	AddPayGrade(g *internal.PayGrade)
+	DeletePayGrade(id string) error
+	UpdatePayGrade(g *internal.PayGrade) error
+	GetEvents(employeeID string) []internal.LifecycleEvent
+	GetAllEvents() []internal.LifecycleEvent
+	AddEvent(e *internal.LifecycleEvent)
+	EmployeesVersion() uint64
+	GetDeletedEmployees() map[string]internal.Employee
+	GetDeletedPositions() map[string]internal.Position
+	GetExchangeRates() []internal.ExchangeRate
+	SetExchangeRate(r *internal.ExchangeRate)
+	DeleteExchangeRate(id string) error
+	GetAttributeDefinitions() []internal.AttributeDefinition
+	AddAttributeDefinition(d *internal.AttributeDefinition)
+	UpdateAttributeDefinition(d *internal.AttributeDefinition) error
+	DeleteAttributeDefinition(id string) error
+	GetAPIKeys() []internal.APIKey
+	GetAPIKey(id string) (internal.APIKey, error)
+	FindAPIKeyByHash(hash string) (internal.APIKey, error)
+	AddAPIKey(k *internal.APIKey)
+	UpdateAPIKey(k *internal.APIKey) error
+	TouchAPIKey(id string, at time.Time) error
 }
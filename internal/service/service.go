@@ -4,40 +4,80 @@ import (
 	"context"
 
 	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
 	"github.com/VTerenya/employees/internal/errors"
+	"github.com/VTerenya/employees/internal/requestctx"
 	"github.com/google/uuid"
-	"github.com/sirupsen/logrus"
 )
 
 type Serv struct {
-	repo Repository
+	repo         Repository
+	baseCurrency string
+	spans        *spanCache
 }
 
-func NewServ(repository Repository) *Serv {
+func NewServ(repository Repository, baseCurrency string) *Serv {
+	if baseCurrency == "" {
+		baseCurrency = internal.DefaultCurrency
+	}
 	return &Serv{
-		repo: repository,
+		repo:         repository,
+		baseCurrency: baseCurrency,
+		spans:        &spanCache{},
 	}
 }
 
-func logCorrelationID(ctx context.Context) error {
-	correlationID, ok := ctx.Value("correlation_id").(string)
-	if !ok {
+func logOperation(ctx context.Context, operation string) error {
+	if requestctx.CorrelationID(ctx) == "" {
 		return errors.StatusInternalServerError()
 	}
-	logrus.WithFields(logrus.Fields{
-		"correlation_id": correlationID,
-	}).Info()
+	requestctx.Logger(ctx).WithField("operation", operation).Debug()
+	return nil
+}
+
+func authorize(ctx context.Context, permissions ...string) error {
+	for _, permission := range permissions {
+		if !auth.Can(ctx, permission) {
+			return errors.Forbidden()
+		}
+	}
 	return nil
 }
 
 func (t Serv) CreatePosition(ctx context.Context, p *internal.Position) error {
-	err := logCorrelationID(ctx)
+	err := logOperation(ctx, "CreatePosition")
+	if err != nil {
+		return err
+	}
+	err = authorize(ctx, auth.PermPositionsWrite)
+	if err != nil {
+		return err
+	}
+	if !p.Salary.IsZero() || p.PayGradeID != nil || p.SalaryOverrideReason != "" {
+		err = authorize(ctx, auth.PermSalaryWrite)
+		if err != nil {
+			return err
+		}
+	}
+	err = t.checkDepartment(p.DepartmentID)
+	if err != nil {
+		return err
+	}
+	err = t.checkCurrency(&p.Currency, "")
+	if err != nil {
+		return err
+	}
+	err = t.checkSalaryBand(p)
+	if err != nil {
+		return err
+	}
+	p.Attributes, err = t.checkAttributes(internal.EntityPosition, p.Attributes)
 	if err != nil {
 		return err
 	}
 	m := t.repo.GetPositions()
 	for _, value := range m {
-		if value.Salary.String() == p.Salary.String() && value.Name == p.Name {
+		if value.Salary.String() == p.Salary.String() && value.Currency == p.Currency && value.Name == p.Name {
 			return errors.PositionIsExists()
 		}
 	}
@@ -47,10 +87,30 @@ func (t Serv) CreatePosition(ctx context.Context, p *internal.Position) error {
 }
 
 func (t Serv) CreateEmployee(ctx context.Context, e *internal.Employee) error {
-	err := logCorrelationID(ctx)
+	err := logOperation(ctx, "CreateEmployee")
+	if err != nil {
+		return err
+	}
+	err = authorize(ctx, auth.PermEmployeesWrite)
+	if err != nil {
+		return err
+	}
+	// The id is assigned below; a client-supplied one must not let the
+	// profile checks skip the employee it names.
+	e.ID = uuid.Nil
+	err = t.checkNewEmployee(e)
 	if err != nil {
 		return err
 	}
+	e.ID = uuid.New()
+	e.Status = internal.StatusActive
+	t.repo.AddEmployee(e)
+	return nil
+}
+
+// checkNewEmployee validates an employee that is not stored yet against the
+// employees and positions that are.
+func (t Serv) checkNewEmployee(e *internal.Employee) error {
 	m := t.repo.GetEmployees()
 	p := t.repo.GetPositions()
 	ok := false
@@ -63,14 +123,24 @@ func (t Serv) CreateEmployee(ctx context.Context, e *internal.Employee) error {
 	if !ok {
 		return errors.PositionIsNotExists()
 	}
+	err := t.checkManager(e)
+	if err != nil {
+		return err
+	}
+	err = t.checkProfile(e)
+	if err != nil {
+		return err
+	}
+	e.Attributes, err = t.checkAttributes(internal.EntityEmployee, e.Attributes)
+	if err != nil {
+		return err
+	}
 	for _, value := range m {
-		if value.LasName == e.LasName &&
+		if value.LastName == e.LastName &&
 			value.FirstName == e.FirstName {
 			return errors.EmployeeIsExists()
 		}
 	}
-	e.ID = uuid.New()
-	t.repo.AddEmployee(e)
 	return nil
 }
 
@@ -78,7 +148,11 @@ func (t Serv) GetPositions(ctx context.Context, limit, offset int) ([]internal.P
 	if limit > 100 {
 		return nil, errors.BadRequest()
 	}
-	err := logCorrelationID(ctx)
+	err := logOperation(ctx, "GetPositions")
+	if err != nil {
+		return nil, err
+	}
+	err = authorize(ctx, auth.PermPositionsRead)
 	if err != nil {
 		return nil, err
 	}
@@ -91,6 +165,7 @@ func (t Serv) GetPositions(ctx context.Context, limit, offset int) ([]internal.P
 	for _, value := range m {
 		positions = append(positions, value)
 	}
+	sortPositions(positions)
 	offset--
 	if float64(len(positions))/float64(limit) <= float64(offset) || limit < 1 || offset < 0 {
 		return nil, errors.NotFound()
@@ -101,11 +176,17 @@ func (t Serv) GetPositions(ctx context.Context, limit, offset int) ([]internal.P
 	return answer, nil
 }
 
-func (t Serv) GetEmployees(ctx context.Context, limit, offset int) ([]internal.Employee, error) {
+func (t Serv) GetEmployees(
+	ctx context.Context, limit, offset int, filter internal.EmployeeFilter,
+) ([]internal.Employee, error) {
 	if limit > 100 {
 		return nil, errors.BadRequest()
 	}
-	err := logCorrelationID(ctx)
+	err := logOperation(ctx, "GetEmployees")
+	if err != nil {
+		return nil, err
+	}
+	err = authorize(ctx, auth.PermEmployeesRead)
 	if err != nil {
 		return nil, err
 	}
@@ -114,10 +195,21 @@ func (t Serv) GetEmployees(ctx context.Context, limit, offset int) ([]internal.E
 	if len(m) == 0 && offset == 1 && limit == 1 {
 		return answer, nil
 	}
+	if filter.Status != "" && !internal.ValidStatus(filter.Status) {
+		return nil, errors.BadRequest()
+	}
+	err = t.checkAttributeFilter(internal.EntityEmployee, filter.Attributes)
+	if err != nil {
+		return nil, err
+	}
 	employees := make([]internal.Employee, 0)
 	for _, value := range m {
-		employees = append(employees, value)
+		if (filter.Status == "" || employeeStatus(value) == filter.Status) &&
+			attributesMatch(value.Attributes, filter.Attributes) {
+			employees = append(employees, value)
+		}
 	}
+	sortEmployees(employees)
 	offset--
 	if float64(len(employees))/float64(limit) <= float64(offset) || limit < 1 || offset < 0 {
 		return nil, errors.NotFound()
@@ -129,7 +221,11 @@ func (t Serv) GetEmployees(ctx context.Context, limit, offset int) ([]internal.E
 }
 
 func (t Serv) GetPosition(ctx context.Context, id string) (internal.Position, error) {
-	err := logCorrelationID(ctx)
+	err := logOperation(ctx, "GetPosition")
+	if err != nil {
+		return internal.Position{}, err
+	}
+	err = authorize(ctx, auth.PermPositionsRead)
 	if err != nil {
 		return internal.Position{}, err
 	}
@@ -147,7 +243,11 @@ func (t Serv) GetPosition(ctx context.Context, id string) (internal.Position, er
 }
 
 func (t Serv) GetEmployee(ctx context.Context, id string) (internal.Employee, error) {
-	err := logCorrelationID(ctx)
+	err := logOperation(ctx, "GetEmployee")
+	if err != nil {
+		return internal.Employee{}, err
+	}
+	err = authorize(ctx, auth.PermEmployeesRead)
 	if err != nil {
 		return internal.Employee{}, err
 	}
@@ -165,7 +265,11 @@ func (t Serv) GetEmployee(ctx context.Context, id string) (internal.Employee, er
 }
 
 func (t Serv) DeletePosition(ctx context.Context, id string) error {
-	err := logCorrelationID(ctx)
+	err := logOperation(ctx, "DeletePosition")
+	if err != nil {
+		return err
+	}
+	err = authorize(ctx, auth.PermPositionsWrite)
 	if err != nil {
 		return err
 	}
@@ -173,31 +277,94 @@ func (t Serv) DeletePosition(ctx context.Context, id string) error {
 }
 
 func (t Serv) DeleteEmployee(ctx context.Context, id string) error {
-	err := logCorrelationID(ctx)
+	err := logOperation(ctx, "DeleteEmployee")
 	if err != nil {
 		return err
 	}
+	err = authorize(ctx, auth.PermEmployeesWrite)
+	if err != nil {
+		return err
+	}
+	employees := t.repo.GetEmployees()
+	if e, ok := employees[id]; ok && employeeStatus(e) != internal.StatusTerminated {
+		return errors.EmployeeIsNotTerminated()
+	}
+	for _, value := range employees {
+		if value.ManagerID != nil && value.ManagerID.String() == id {
+			return errors.EmployeeHasReports()
+		}
+	}
 	return t.repo.DeleteEmployee(id)
 }
 
 func (t Serv) UpdatePosition(ctx context.Context, p *internal.Position) error {
-	err := logCorrelationID(ctx)
+	err := logOperation(ctx, "UpdatePosition")
+	if err != nil {
+		return err
+	}
+	err = authorize(ctx, auth.PermPositionsWrite)
 	if err != nil {
 		return err
 	}
 	if p.ID.String() == uuid.Nil.String() {
 		return errors.BadRequest()
 	}
+	err = t.checkDepartment(p.DepartmentID)
+	if err != nil {
+		return err
+	}
+	current, ok := t.repo.GetPositions()[p.ID.String()]
+	err = t.checkCurrency(&p.Currency, current.Currency)
+	if err != nil {
+		return err
+	}
+	err = t.checkSalaryBand(p)
+	if err != nil {
+		return err
+	}
+	p.Attributes, err = t.checkAttributes(internal.EntityPosition, p.Attributes)
+	if err != nil {
+		return err
+	}
+	if ok && compensationChanged(current, *p) {
+		err = authorize(ctx, auth.PermSalaryWrite)
+		if err != nil {
+			return err
+		}
+	}
 	return t.repo.UpdatePosition(p)
 }
 
 func (t Serv) UpdateEmployee(ctx context.Context, e *internal.Employee) error {
-	err := logCorrelationID(ctx)
+	err := logOperation(ctx, "UpdateEmployee")
+	if err != nil {
+		return err
+	}
+	err = authorize(ctx, auth.PermEmployeesWrite)
 	if err != nil {
 		return err
 	}
 	if e.ID.String() == uuid.Nil.String() {
 		return errors.BadRequest()
 	}
+	current, ok := t.repo.GetEmployees()[e.ID.String()]
+	if ok && e.PositionID != current.PositionID {
+		return errors.PositionNeedsTransfer()
+	}
+	err = t.checkManager(e)
+	if err != nil {
+		return err
+	}
+	err = t.checkProfile(e)
+	if err != nil {
+		return err
+	}
+	e.Attributes, err = t.checkAttributes(internal.EntityEmployee, e.Attributes)
+	if err != nil {
+		return err
+	}
+	if ok {
+		e.Status = current.Status
+	}
 	return t.repo.UpdateEmployee(e)
 }
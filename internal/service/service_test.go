@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	errs "errors"
+	"testing"
+
+	"github.com/VTerenya/employees/internal"
+	"github.com/VTerenya/employees/internal/auth"
+	"github.com/VTerenya/employees/internal/errors"
+	"github.com/VTerenya/employees/internal/repository"
+	"github.com/VTerenya/employees/internal/requestctx"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func newTestServ() *Serv {
+	return NewServ(repository.NewRepo(repository.NewDataBase()), "")
+}
+
+func testContext(permissions ...string) context.Context {
+	ctx := requestctx.WithCorrelationID(context.Background(), "test")
+	if len(permissions) == 0 {
+		return auth.WithIdentity(ctx, auth.AnonymousIdentity())
+	}
+	granted := make(map[string]bool, len(permissions))
+	for _, p := range permissions {
+		granted[p] = true
+	}
+	return auth.WithIdentity(ctx, auth.Identity{Subject: "test", Permissions: granted})
+}
+
+func TestLogOperation(t *testing.T) {
+	hook := test.NewGlobal()
+	level := logrus.GetLevel()
+	logrus.SetLevel(logrus.DebugLevel)
+	defer func() {
+		logrus.SetLevel(level)
+		logrus.StandardLogger().ReplaceHooks(make(logrus.LevelHooks))
+	}()
+
+	if err := logOperation(context.Background(), "GetPosition"); !errs.Is(err, errors.StatusInternalServerError()) {
+		t.Fatalf("without a correlation id: got %v", err)
+	}
+	if len(hook.AllEntries()) != 0 {
+		t.Fatalf("logged %d entries without a correlation id", len(hook.AllEntries()))
+	}
+
+	ctx := requestctx.WithCorrelationID(context.Background(), "req-7")
+	if err := logOperation(ctx, "GetPosition"); err != nil {
+		t.Fatal(err)
+	}
+	entry := hook.LastEntry()
+	if entry == nil || entry.Level != logrus.DebugLevel ||
+		entry.Data["operation"] != "GetPosition" || entry.Data[requestctx.CorrelationIDKey] != "req-7" {
+		t.Fatalf("log entry = %+v", entry)
+	}
+
+	ctx = requestctx.WithLogger(ctx, logrus.NewEntry(logrus.StandardLogger()).WithField("route", "/position/{id}"))
+	if err := logOperation(ctx, "GetPosition"); err != nil {
+		t.Fatal(err)
+	}
+	if entry := hook.LastEntry(); entry.Data["route"] != "/position/{id}" {
+		t.Fatalf("request logger fields were dropped: %+v", entry.Data)
+	}
+}
+
+func TestCreatePositionSalaryPermission(t *testing.T) {
+	s := newTestServ()
+	ctx := testContext(auth.PermPositionsWrite)
+	if err := s.CreatePosition(ctx, &internal.Position{Name: "unpaid"}); err != nil {
+		t.Fatalf("position without salary: %v", err)
+	}
+	err := s.CreatePosition(ctx, &internal.Position{Name: "paid", Salary: decimal.NewFromInt(100)})
+	if !errs.Is(err, errors.Forbidden()) {
+		t.Fatalf("position with salary: got %v, want forbidden", err)
+	}
+	err = s.CreatePosition(testContext(auth.PermPositionsWrite, auth.PermSalaryWrite),
+		&internal.Position{Name: "paid", Salary: decimal.NewFromInt(100)})
+	if err != nil {
+		t.Fatalf("position with salary and salary:write: %v", err)
+	}
+}
+
+func TestServiceRequiresIdentity(t *testing.T) {
+	s := newTestServ()
+	ctx := requestctx.WithCorrelationID(context.Background(), "test")
+	if _, err := s.GetPositions(ctx, 1, 1); !errs.Is(err, errors.Forbidden()) {
+		t.Fatalf("got %v, want forbidden", err)
+	}
+}
+
+func TestCreateEmployeeIgnoresClientID(t *testing.T) {
+	s := newTestServ()
+	position := addPosition(t, s, "dev").ID
+	existing := internal.Employee{FirstName: "ann", LastName: "ann", PositionID: position,
+		Email: "ann@example.com", EmployeeNumber: "E1"}
+	if err := s.CreateEmployee(testContext(), &existing); err != nil {
+		t.Fatal(err)
+	}
+	e := internal.Employee{ID: existing.ID, FirstName: "bob", LastName: "bob", PositionID: position,
+		Email: "ann@example.com"}
+	if err := s.CreateEmployee(testContext(), &e); !errs.Is(err, errors.EmailIsExists()) {
+		t.Fatalf("create with a taken email and its owner's id: got %v", err)
+	}
+	e = internal.Employee{ID: existing.ID, FirstName: "bob", LastName: "bob", PositionID: position,
+		EmployeeNumber: "E1"}
+	if err := s.HireEmployee(testContext(), &e, internal.Date{}); !errs.Is(err, errors.EmployeeNumberIsExists()) {
+		t.Fatalf("hire with a taken number and its owner's id: got %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/VTerenya/employees/internal/config"
+	"github.com/sirupsen/logrus"
+)
+
+type Reloader struct {
+	cfg config.TLS
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTime   map[string]time.Time
+}
+
+func NewReloader(cfg config.TLS) (*Reloader, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, fmt.Errorf("tls is enabled but cert_file or key_file is not set")
+	}
+	if cfg.RequireClientCert && cfg.ClientCAFile == "" {
+		return nil, fmt.Errorf("require_client_cert is set but client_ca_file is not")
+	}
+	r := &Reloader{cfg: cfg, modTime: map[string]time.Time{}}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+	return files
+}
+
+func (r *Reloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return err
+	}
+	var pool *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		data, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return err
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return fmt.Errorf("%s: no certificates found", r.cfg.ClientCAFile)
+		}
+	}
+	modTime := map[string]time.Time{}
+	for _, f := range r.files() {
+		if info, err := os.Stat(f); err == nil {
+			modTime[f] = info.ModTime()
+		}
+	}
+	r.mu.Lock()
+	r.cert, r.clientCAs, r.modTime = &cert, pool, modTime
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *Reloader) changed() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, f := range r.files() {
+		info, err := os.Stat(f)
+		if err != nil {
+			continue
+		}
+		if !info.ModTime().Equal(r.modTime[f]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reloader) Watch(stop <-chan struct{}) {
+	interval := time.Duration(r.cfg.ReloadIntervalSeconds) * time.Second
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			r.reloadIfChanged()
+		}
+	}
+}
+
+func (r *Reloader) reloadIfChanged() {
+	if !r.changed() {
+		return
+	}
+	if err := r.reload(); err != nil {
+		logrus.WithError(err).Error("tls reload failed, keeping previous certificates")
+		return
+	}
+	logrus.Info("tls certificates reloaded")
+}
+
+func (r *Reloader) clientAuth() tls.ClientAuthType {
+	switch {
+	case r.cfg.ClientCAFile == "":
+		return tls.NoClientCert
+	case r.cfg.RequireClientCert:
+		return tls.RequireAndVerifyClientCert
+	default:
+		return tls.VerifyClientCertIfGiven
+	}
+}
+
+func (r *Reloader) certificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// TLSConfig resolves the current certificates on every handshake. It sets
+// GetCertificate as well as GetConfigForClient because older releases of
+// http.Server.ServeTLS only check Certificates and GetCertificate to decide
+// that no key files are needed.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		NextProtos:     []string{"h2", "http/1.1"},
+		GetCertificate: r.certificate,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   []string{"h2", "http/1.1"},
+				Certificates: []tls.Certificate{*r.cert},
+				ClientCAs:    r.clientCAs,
+				ClientAuth:   r.clientAuth(),
+			}, nil
+		},
+	}
+}
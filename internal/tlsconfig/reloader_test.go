@@ -0,0 +1,106 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/VTerenya/employees/internal/config"
+)
+
+func writeKeyPair(t *testing.T, certFile, keyFile, name string, modTime time.Time) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for file, block := range map[string]*pem.Block{
+		certFile: {Type: "CERTIFICATE", Bytes: der},
+		keyFile:  {Type: "EC PRIVATE KEY", Bytes: keyDER},
+	} {
+		if err := os.WriteFile(file, pem.EncodeToMemory(block), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func leafName(t *testing.T, cert *tls.Certificate) string {
+	t.Helper()
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leaf.Subject.CommonName
+}
+
+func TestReloadPicksUpNewCertificate(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.TLS{CertFile: filepath.Join(dir, "tls.crt"), KeyFile: filepath.Join(dir, "tls.key")}
+	start := time.Now().Add(-time.Minute)
+	writeKeyPair(t, cfg.CertFile, cfg.KeyFile, "old.example.com", start)
+	r, err := NewReloader(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tlsConfig := r.TLSConfig()
+	current := func() (string, string) {
+		cert, err := tlsConfig.GetCertificate(&tls.ClientHelloInfo{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		perClient, err := tlsConfig.GetConfigForClient(&tls.ClientHelloInfo{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return leafName(t, cert), leafName(t, &perClient.Certificates[0])
+	}
+	if got, _ := current(); got != "old.example.com" {
+		t.Fatalf("initial certificate = %s", got)
+	}
+
+	r.reloadIfChanged()
+	if got, _ := current(); got != "old.example.com" {
+		t.Fatalf("certificate after a reload without changes = %s", got)
+	}
+
+	writeKeyPair(t, cfg.CertFile, cfg.KeyFile, "new.example.com", start.Add(time.Second))
+	r.reloadIfChanged()
+	if got, perClient := current(); got != "new.example.com" || perClient != "new.example.com" {
+		t.Fatalf("certificates after reload = %s, %s", got, perClient)
+	}
+
+	if err := os.WriteFile(cfg.KeyFile, []byte("garbage"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	r.reloadIfChanged()
+	if got, _ := current(); got != "new.example.com" {
+		t.Fatalf("certificate after a failed reload = %s", got)
+	}
+}